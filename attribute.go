@@ -0,0 +1,334 @@
+package kindsys
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/token"
+	"github.com/grafana/thema"
+)
+
+// AttributeParser parses and validates the contents of a single CUE attribute
+// in a kind schema, such as @cuetsy(kind="interface"). It returns a value
+// representing the parsed attribute, or an error describing why the attribute
+// is malformed.
+type AttributeParser func(attr cue.Attribute) (any, error)
+
+var attrParsers = struct {
+	sync.RWMutex
+	m map[string]AttributeParser
+}{m: make(map[string]AttributeParser)}
+
+func init() {
+	RegisterAttribute("cuetsy", parseCuetsyAttribute)
+	RegisterAttribute("grafanamaturity", parseGrafanaMaturityAttribute)
+	RegisterAttribute("kindsys", parseKindsysAttribute)
+}
+
+// RegisterAttribute registers a parser for CUE attributes with the provided
+// name. Once registered, all attributes with that name in a kind's schemas are
+// parsed when the kind's lineage is bound, and any errors fail the binding.
+//
+// RegisterAttribute is intended to be called from init(). It panics if the
+// name is empty or already registered.
+func RegisterAttribute(name string, parse AttributeParser) {
+	if name == "" || parse == nil {
+		panic("kindsys: RegisterAttribute requires a name and a parser")
+	}
+	attrParsers.Lock()
+	defer attrParsers.Unlock()
+	if _, has := attrParsers.m[name]; has {
+		panic(fmt.Sprintf("kindsys: attribute %q already registered", name))
+	}
+	attrParsers.m[name] = parse
+}
+
+// RegisteredAttributes returns the sorted names of all attributes with a
+// registered parser.
+func RegisteredAttributes() []string {
+	attrParsers.RLock()
+	defer attrParsers.RUnlock()
+	names := make([]string, 0, len(attrParsers.m))
+	for name := range attrParsers.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func attrParser(name string) (AttributeParser, bool) {
+	attrParsers.RLock()
+	defer attrParsers.RUnlock()
+	p, has := attrParsers.m[name]
+	return p, has
+}
+
+// Attribute is a registered CUE attribute found on a field in a kind schema,
+// together with its parsed value.
+type Attribute struct {
+	// Name is the name of the attribute, e.g. "cuetsy" for @cuetsy(...).
+	Name string
+	// Path is the path to the attributed field within the schema, with list
+	// elements written as "[]", e.g. "spec.panels[].title". Definitions are
+	// included, e.g. "#DataQuery".
+	Path string
+	// Pos is the position of the attribute in CUE source, if known.
+	Pos token.Pos
+	// Raw is the unparsed attribute.
+	Raw cue.Attribute
+	// Value is the value returned from the attribute's registered
+	// [AttributeParser].
+	Value any
+}
+
+// SchemaAttributes holds all the registered attributes in a single schema,
+// and allows querying them by path and name.
+type SchemaAttributes struct {
+	// Schema is the version of the schema containing the attributes.
+	Schema thema.SyntacticVersion
+
+	all    []Attribute
+	byPath map[string][]int
+}
+
+// All returns all attributes in the schema, in field order.
+func (sa *SchemaAttributes) All() []Attribute {
+	return sa.all
+}
+
+// At returns the attributes on the field at the provided path.
+func (sa *SchemaAttributes) At(path string) []Attribute {
+	attrs := make([]Attribute, 0, len(sa.byPath[path]))
+	for _, i := range sa.byPath[path] {
+		attrs = append(attrs, sa.all[i])
+	}
+	return attrs
+}
+
+// Lookup returns the first attribute with the provided name on the field at
+// the provided path.
+func (sa *SchemaAttributes) Lookup(path, name string) (Attribute, bool) {
+	for _, i := range sa.byPath[path] {
+		if sa.all[i].Name == name {
+			return sa.all[i], true
+		}
+	}
+	return Attribute{}, false
+}
+
+// Named returns all attributes with the provided name, in field order.
+func (sa *SchemaAttributes) Named(name string) []Attribute {
+	var attrs []Attribute
+	for _, attr := range sa.all {
+		if attr.Name == name {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+// ParseSchemaAttributes parses all the registered attributes in the provided
+// schema.
+//
+// Attributes with no registered parser are ignored. If any attributes are
+// malformed, the returned error is an [AttributeErrors], and the returned
+// SchemaAttributes contains all the attributes that were not.
+func ParseSchemaAttributes(sch thema.Schema) (*SchemaAttributes, error) {
+	defer lockCUE(sch.Underlying().Context())()
+	return parseSchemaAttributes(sch)
+}
+
+func parseSchemaAttributes(sch thema.Schema) (*SchemaAttributes, error) {
+	sa := &SchemaAttributes{
+		Schema: sch.Version(),
+		byPath: make(map[string][]int),
+	}
+
+	var errs AttributeErrors
+	// Attributes on referenced definitions appear at each referring field.
+	// Report each malformed one only once.
+	failed := make(map[token.Pos]bool)
+	cfg := WalkFieldsConfig{Definitions: true, References: true}
+	WalkFields(sch.Underlying().LookupPath(cue.ParsePath("schema")), cfg, func(f Field) {
+		p, v := f.Path, f.Value
+		positions := attrPositions(v)
+		for _, raw := range v.Attributes(cue.ValueAttr) {
+			name := raw.Name()
+			parse, has := attrParser(name)
+			if !has {
+				continue
+			}
+
+			attr := Attribute{
+				Name: name,
+				Path: p,
+				Pos:  v.Pos(),
+				Raw:  raw,
+			}
+			if pos, has := positions[name]; has && len(pos) > 0 {
+				attr.Pos, positions[name] = pos[0], pos[1:]
+			}
+
+			var err error
+			if attr.Value, err = parse(raw); err != nil {
+				if !failed[attr.Pos] {
+					failed[attr.Pos] = true
+					errs = append(errs, &AttributeError{Attribute: attr, Schema: sa.Schema, Err: err})
+				}
+				continue
+			}
+			sa.byPath[p] = append(sa.byPath[p], len(sa.all))
+			sa.all = append(sa.all, attr)
+		}
+	})
+
+	if len(errs) > 0 {
+		return sa, errs
+	}
+	return sa, nil
+}
+
+// validateAttributes parses the registered attributes in every schema in the
+// lineage, returning any errors.
+func validateAttributes(lin thema.Lineage) error {
+	var errs AttributeErrors
+	for sch := lin.First(); sch != nil; sch = sch.Successor() {
+		if _, err := parseSchemaAttributes(sch); err != nil {
+			errs = append(errs, err.(AttributeErrors)...)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// attrPositions returns the positions of the attributes on the field value v,
+// keyed by name. cue.Attribute carries no position, so they are taken from the
+// field's syntax, where available.
+func attrPositions(v cue.Value) map[string][]token.Pos {
+	f, ok := v.Source().(*ast.Field)
+	if !ok {
+		return nil
+	}
+	positions := make(map[string][]token.Pos)
+	for _, a := range f.Attrs {
+		key, _ := a.Split()
+		positions[key] = append(positions[key], a.Pos())
+	}
+	return positions
+}
+
+// AttributeError describes a single malformed attribute in a kind schema.
+type AttributeError struct {
+	Attribute
+	// Schema is the version of the schema containing the attribute.
+	Schema thema.SyntacticVersion
+	// Err is the error returned from the attribute's parser.
+	Err error
+}
+
+func (e *AttributeError) Error() string {
+	return fmt.Sprintf("%s: schema %s: %s: invalid @%s attribute: %s", e.Pos, e.Schema, e.Path, e.Name, e.Err)
+}
+
+func (e *AttributeError) Unwrap() error {
+	return e.Err
+}
+
+// AttributeErrors aggregates the [AttributeError]s from parsing the
+// attributes in one or more kind schemas.
+type AttributeErrors []*AttributeError
+
+func (e AttributeErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d invalid attribute(s):\n%s", len(e), strings.Join(msgs, "\n"))
+}
+
+// CuetsyAttribute is the parsed form of a @cuetsy attribute, which governs
+// TypeScript code generation. See https://github.com/grafana/cuetsy#usage.
+type CuetsyAttribute struct {
+	// Kind is the kind of TypeScript declaration to generate: one of "type",
+	// "interface" or "enum".
+	Kind string
+	// MemberNames are the names of the members of an enum, if given.
+	MemberNames []string
+}
+
+func parseCuetsyAttribute(attr cue.Attribute) (any, error) {
+	var ca CuetsyAttribute
+	for i := 0; i < attr.NumArgs(); i++ {
+		key, val := attr.Arg(i)
+		switch key {
+		case "kind":
+			switch val {
+			case "type", "interface", "enum":
+				ca.Kind = val
+			default:
+				return nil, fmt.Errorf("kind must be one of type, interface or enum, got %q", val)
+			}
+		case "memberNames":
+			ca.MemberNames = strings.Split(val, "|")
+		default:
+			return nil, fmt.Errorf("unknown argument %q", key)
+		}
+	}
+
+	switch {
+	case ca.Kind == "":
+		return nil, errors.New("kind is required")
+	case ca.MemberNames != nil && ca.Kind != "enum":
+		return nil, fmt.Errorf("memberNames is only valid with kind=\"enum\", got kind=%q", ca.Kind)
+	}
+	return ca, nil
+}
+
+// GrafanaMaturityAttribute is the parsed form of a @grafanamaturity attribute,
+// which marks a schema field as needing further work before its kind may
+// advance in maturity.
+type GrafanaMaturityAttribute struct {
+	// Args holds the positional arguments, e.g. NeedsExpertReview.
+	Args []string
+	// Params holds the key=value arguments.
+	Params map[string]string
+}
+
+func parseGrafanaMaturityAttribute(attr cue.Attribute) (any, error) {
+	if strings.TrimSpace(attr.Contents()) == "" {
+		return nil, errors.New("at least one positional argument is required, e.g. @grafanamaturity(NeedsExpertReview)")
+	}
+
+	var ga GrafanaMaturityAttribute
+	for i := 0; i < attr.NumArgs(); i++ {
+		key, val := attr.Arg(i)
+		if !strings.Contains(attr.RawArg(i), "=") {
+			if key == "" {
+				return nil, fmt.Errorf("empty argument at position %d", i)
+			}
+			ga.Args = append(ga.Args, key)
+			continue
+		}
+		if ga.Params == nil {
+			ga.Params = make(map[string]string)
+		}
+		ga.Params[key] = val
+	}
+	if len(ga.Args) == 0 {
+		return nil, errors.New("at least one positional argument is required, e.g. @grafanamaturity(NeedsExpertReview)")
+	}
+	return ga, nil
+}
+
+func parseKindsysAttribute(attr cue.Attribute) (any, error) {
+	// kindsys attributes are file-level, e.g. @kindsys(framework="v0"), and
+	// are handled by the loader. None are yet defined for schema fields.
+	return nil, fmt.Errorf("@kindsys(%s) is not valid on schema fields", attr.Contents())
+}
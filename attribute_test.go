@@ -0,0 +1,133 @@
+package kindsys
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/grafana/thema"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	RegisterAttribute("kindsystest", func(attr cue.Attribute) (any, error) {
+		if attr.Contents() != "ok" {
+			return nil, errors.New(`must be @kindsystest(ok)`)
+		}
+		return "parsed", nil
+	})
+}
+
+func bindAttrTestKind(t *testing.T, schema string) (Core, error) {
+	t.Helper()
+	ctx := cuecontext.New()
+	def, err := LoadCoreKindDef(testKindFS(`package kind
+
+import "github.com/grafana/kindsys"
+
+kindsys.Core
+name:        "TestAttrs"
+description: "A core kind for tests."
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+`+schema+`
+	}
+}]
+`), ".", ctx)
+	require.NoError(t, err)
+	return BindCore(thema.NewRuntime(ctx), def)
+}
+
+func TestParseSchemaAttributes(t *testing.T) {
+	k, err := bindAttrTestKind(t, `
+		#Thing: {
+			kind: "a" | "b" @cuetsy(kind="enum", memberNames="A|B")
+		} @cuetsy(kind="interface")
+		spec: {
+			title: string @grafanamaturity(NeedsExpertReview) @kindsystest(ok) @unregistered(whatever)
+			things: [...#Thing]
+		}`)
+	require.NoError(t, err)
+
+	sa, err := ParseSchemaAttributes(k.Lineage().Latest())
+	require.NoError(t, err)
+	// including those inherited by referring fields
+	require.Len(t, sa.All(), 5)
+
+	attr, has := sa.Lookup("#Thing", "cuetsy")
+	require.True(t, has)
+	require.Equal(t, CuetsyAttribute{Kind: "interface"}, attr.Value)
+	attr, has = sa.Lookup("#Thing.kind", "cuetsy")
+	require.True(t, has)
+	require.Equal(t, CuetsyAttribute{Kind: "enum", MemberNames: []string{"A", "B"}}, attr.Value)
+
+	title := sa.At("spec.title")
+	require.Len(t, title, 2)
+	require.Equal(t, GrafanaMaturityAttribute{Args: []string{"NeedsExpertReview"}}, title[0].Value)
+	require.Equal(t, "parsed", title[1].Value)
+	require.Equal(t, 16, title[0].Pos.Line())
+
+	require.Len(t, sa.Named("cuetsy"), 3)
+	attr, has = sa.Lookup("spec.things[].kind", "cuetsy")
+	require.True(t, has)
+	require.Equal(t, "enum", attr.Value.(CuetsyAttribute).Kind)
+	_, has = sa.Lookup("spec.title", "unregistered")
+	require.False(t, has)
+}
+
+func TestMalformedAttributes(t *testing.T) {
+	tests := map[string]struct {
+		schema string
+		err    string
+	}{
+		"cuetsy bad kind": {
+			schema: `spec: {} @cuetsy(kind="klass")`,
+			err:    `spec: invalid @cuetsy attribute: kind must be one of type, interface or enum, got "klass"`,
+		},
+		"cuetsy memberNames without enum": {
+			schema: `spec: {} @cuetsy(kind="interface", memberNames="A|B")`,
+			err:    `memberNames is only valid with kind="enum"`,
+		},
+		"grafanamaturity without args": {
+			schema: `spec: title: string @grafanamaturity()`,
+			err:    `spec.title: invalid @grafanamaturity attribute: at least one positional argument is required`,
+		},
+		"kindsys on a field": {
+			schema: `spec: title: string @kindsys(framework="v0")`,
+			err:    `@kindsys(framework="v0") is not valid on schema fields`,
+		},
+		"in a referenced definition": {
+			schema: `#Thing: {} @cuetsy(kind="klass")
+		spec: a: #Thing
+		spec: b: #Thing`,
+			err: `#Thing: invalid @cuetsy attribute`,
+		},
+		"registered elsewhere": {
+			schema: `spec: title: string @kindsystest(nope)`,
+			err:    `must be @kindsystest(ok)`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := bindAttrTestKind(t, tt.schema)
+			var aerrs AttributeErrors
+			require.True(t, errors.As(err, &aerrs), err)
+			require.Len(t, aerrs, 1)
+			require.Contains(t, err.Error(), tt.err)
+			// positioned at the attribute
+			require.True(t, strings.HasPrefix(aerrs[0].Error(), aerrs[0].Pos.String()))
+			require.Equal(t, 11, aerrs[0].Pos.Line())
+		})
+	}
+}
+
+func TestRegisterAttributeTwice(t *testing.T) {
+	require.Contains(t, RegisteredAttributes(), "cuetsy")
+	require.Panics(t, func() {
+		RegisterAttribute("cuetsy", parseCuetsyAttribute)
+	})
+}
@@ -1,8 +1,13 @@
 package kindsys
 
 import (
-	"cuelang.org/go/cue"
 	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"cuelang.org/go/cue"
 	"github.com/grafana/kindsys/encoding"
 	"github.com/grafana/thema"
 	"github.com/grafana/thema/vmux"
@@ -23,13 +28,21 @@ type withLineage interface {
 	Lineage() thema.Lineage
 }
 
-func bytesToAnyInstance(k withLineage, b []byte, codec Decoder) (*thema.Instance, error) {
+func bytesToAnyInstance(k withLineage, b []byte, codec Decoder) (*thema.Instance, encoding.GrafanaShapeBytes, error) {
 	// Transform from k8s shape to intermediate grafana shape
-	var gb encoding.GrafanaShapeBytes
 	gb, err := codec.Decode(b)
 	if err != nil {
-		return nil, err
+		return nil, gb, err
 	}
+	inst, err := grafanaShapeToInstance(k, gb)
+	return inst, gb, err
+}
+
+// grafanaShapeToInstance validates the intermediate grafana shape against the
+// schemas in the kind's lineage. The schema named by the object's version is
+// tried first, if there is one, then the kind's current schema, then all
+// others.
+func grafanaShapeToInstance(k withLineage, gb encoding.GrafanaShapeBytes) (*thema.Instance, error) {
 	//if gb.Group != k.Group() || gb.Kind != k.Name() {
 	//	return nil, fmt.Errorf("resource is %s.%s, not of kind %s.%s", gb.Group, gb.Kind, k.Group(), k.Name())
 	//}
@@ -53,23 +66,28 @@ func bytesToAnyInstance(k withLineage, b []byte, codec Decoder) (*thema.Instance
 		return nil, err
 	}
 
-	// TODO take advantage of apiVersion of object to pick the right schema to validate against
-	sch, _ := lin.Schema(k.CurrentVersion()) // we verified at bind of this kind that this schema exists
-	inst, curvererr := sch.Validate(cval)
-	if curvererr != nil {
+	var first thema.Schema
+	if v, err := ParseAPIVersion(gb.Version); err == nil {
+		first, _ = lin.Schema(v) //nolint:errcheck
+	}
+	if first == nil {
+		first, _ = lin.Schema(k.CurrentVersion()) // we verified at bind of this kind that this schema exists
+	}
+	inst, firsterr := first.Validate(cval)
+	if firsterr != nil {
 		for sch := lin.First(); sch != nil; sch = sch.Successor() {
-			if sch.Version() == k.CurrentVersion() {
+			if sch.Version() == first.Version() {
 				continue
 			}
 			if inst, err = sch.Validate(cval); err == nil {
-				curvererr = nil
+				firsterr = nil
 				break
 			}
 		}
 	}
 
 	// TODO improve this once thema stacks all schema validation errors https://github.com/grafana/thema/issues/156
-	return inst, curvererr
+	return inst, firsterr
 }
 
 // TODO this is why we need to combine [Core] and [Custom]
@@ -89,7 +107,7 @@ type grafanaShape struct {
 }
 
 // FIXME this is a fugly temporary hack - make this go away when we have clarity on our different shapes and the types line up
-func grafanaShapeToUnstructured(k resourceKind, inst *thema.Instance) (*UnstructuredResource, error) {
+func grafanaShapeToUnstructured(k resourceKind, inst *thema.Instance, gb encoding.GrafanaShapeBytes) (*UnstructuredResource, error) {
 	gs := grafanaShape{}
 	err := inst.Underlying().Decode(&gs)
 	if err != nil {
@@ -101,6 +119,9 @@ func grafanaShapeToUnstructured(k resourceKind, inst *thema.Instance) (*Unstruct
 	u.StaticMeta.Group = k.Group()
 	u.StaticMeta.Kind = k.Name()
 	u.StaticMeta.Version = gs.Version
+	if u.StaticMeta.Version == "" {
+		u.StaticMeta.Version = gb.Version
+	}
 	// TODO what are we doing about namespace?
 	if ns, has := gs.Metadata["namespace"]; has {
 		u.StaticMeta.Namespace = ns.(string)
@@ -118,5 +139,187 @@ func grafanaShapeToUnstructured(k resourceKind, inst *thema.Instance) (*Unstruct
 	// NOTE this doesn't populate anything right now
 	u.CustomMeta = gs.CustomMeta
 
+	u.Spec = gs.Spec
+	// status is not validated, so take it as decoded
+	if status, has := gb.Subresources["status"]; has {
+		if err = json.Unmarshal(status, &u.Status); err != nil {
+			return nil, fmt.Errorf("unable to decode status: %w", err)
+		}
+	}
+
 	return u, nil
 }
+
+// newResource returns a new, zero value of the resource type R, which must be
+// a pointer to a struct.
+func newResource[R Resource]() (R, error) {
+	var r R
+	rt := reflect.TypeOf((*R)(nil)).Elem()
+	if rt.Kind() != reflect.Pointer || rt.Elem().Kind() != reflect.Struct {
+		return r, fmt.Errorf("resource type %s is not a pointer to a struct", rt)
+	}
+	return reflect.New(rt.Elem()).Interface().(R), nil
+}
+
+// checkResource checks that the objects of the current schema of the kind k
+// are assignable to the resource type R, by the rules of
+// [thema.AssignableTo]. The spec, custom metadata and subresources of the
+// schema are checked against the fields of R that unstructuredToTyped
+// unmarshals them into. Custom metadata is only checked when R represents it
+// with a struct, and common metadata is not checked, as R's setters take it.
+func checkResource[R Resource](k interface {
+	resourceKind
+	Lineage() thema.Lineage
+	CurrentVersion() thema.SyntacticVersion
+}) error {
+	r, err := newResource[R]()
+	if err != nil {
+		return err
+	}
+	sch, err := k.Lineage().Schema(k.CurrentVersion())
+	if err != nil {
+		return err
+	}
+
+	defer lockCUE(k.Lineage().Runtime().Context())()
+	// the schema as checked by thema.AssignableTo, joined with the CRD schema
+	v := sch.Underlying().LookupPath(cue.MakePath(cue.Hid("_#schema", "github.com/grafana/thema")))
+	fields := jsonFields(reflect.TypeOf(r).Elem())
+	if _, has := fields["spec"]; !has {
+		return fmt.Errorf("resource type %T of kind %s has no spec field", r, k.Name())
+	}
+	// only the fields of the schema are set on R, so no others are checked
+	for name := range fields {
+		if name != "spec" && name != "customMetadata" && !lookupField(v, name).Exists() {
+			delete(fields, name)
+		}
+	}
+	if cm, has := fields["customMetadata"]; has {
+		delete(fields, "customMetadata")
+		mt := cm.Type
+		if mt.Kind() == reflect.Pointer {
+			mt = mt.Elem()
+		}
+		if mt.Kind() == reflect.Struct && lookupField(v, "metadata").Exists() {
+			st, err := structType(lookupField(v, "metadata"), jsonFields(mt))
+			if err != nil {
+				return err
+			}
+			fields["metadata"] = reflect.StructField{Type: st}
+		}
+	}
+	st, err := structType(v, fields)
+	if err != nil {
+		return err
+	}
+	if err = thema.AssignableTo(sch, reflect.New(st).Interface()); err != nil {
+		return fmt.Errorf("resource type %T is not assignable to schema %s of kind %s: %w", r, sch.Version(), k.Name(), err)
+	}
+	return nil
+}
+
+// structType returns a struct type with a field for each field of the struct
+// value v, so that Go types may be checked against the fields of v by
+// [thema.AssignableTo]. A field named in fields, by its JSON name, is of that
+// field's type, and takes its tag if it has one; every other field is of type
+// any. Fields not in v are kept, so that their absence from v is reported.
+//
+// The caller must hold the CUE lock of v's context.
+func structType(v cue.Value, fields map[string]reflect.StructField) (reflect.Type, error) {
+	iter, err := v.Fields(cue.Optional(true))
+	if err != nil {
+		return nil, err
+	}
+
+	var sfs []reflect.StructField
+	seen := make(map[string]bool)
+	add := func(name string, optional bool) {
+		sf := reflect.StructField{
+			Name: fmt.Sprintf("F%d", len(sfs)),
+			Type: reflect.TypeOf((*any)(nil)).Elem(),
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s"`, name)),
+		}
+		if optional {
+			sf.Tag = reflect.StructTag(fmt.Sprintf(`json:"%s,omitempty"`, name))
+		}
+		if f, has := fields[name]; has {
+			sf.Type = f.Type
+			if f.Tag != "" {
+				sf.Tag = f.Tag
+			}
+		}
+		sfs = append(sfs, sf)
+		seen[name] = true
+	}
+	for iter.Next() {
+		add(iter.Selector().Unquoted(), iter.IsOptional())
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add(name, false)
+	}
+	return reflect.StructOf(sfs), nil
+}
+
+// jsonFields returns the exported fields of the struct type t, including
+// those promoted from embedded structs, by the name they are encoded with
+// in JSON.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for _, f := range reflect.VisibleFields(t) {
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		tag := name
+		if opts != "" {
+			tag += "," + opts
+		}
+		fields[name] = reflect.StructField{
+			Type: f.Type,
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s"`, tag)),
+		}
+	}
+	return fields
+}
+
+// unstructuredToTyped converts u to the resource type R. The metadata of u is
+// set through R's setters, while its spec, custom metadata and subresources
+// are unmarshaled from JSON into the fields of R so named, as on the structs
+// generated by GoResourceJenny in pkg/codegen.
+func unstructuredToTyped[R Resource](u *UnstructuredResource) (R, error) {
+	r, err := newResource[R]()
+	if err != nil {
+		return r, err
+	}
+	r.SetStaticMetadata(u.StaticMeta)
+	r.SetCommonMetadata(u.CommonMeta)
+
+	fields := map[string]any{
+		"spec":           u.Spec,
+		"customMetadata": u.CustomMeta,
+	}
+	for name, sub := range u.Subresources() {
+		fields[name] = sub
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return r, err
+	}
+	if err = json.Unmarshal(b, r); err != nil {
+		return r, fmt.Errorf("unable to unmarshal into %T: %w", r, err)
+	}
+	return r, nil
+}
@@ -1,6 +1,16 @@
 package kindsys
 
-import "github.com/grafana/thema"
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/errors"
+	"github.com/grafana/thema"
+	"github.com/grafana/thema/vmux"
+)
 
 // genericComposable is a general representation of a parsed and validated
 // Composable kind.
@@ -39,15 +49,305 @@ func (k genericComposable) Lineage() thema.Lineage {
 	return k.lin
 }
 
-// TODO docs
+func (k genericComposable) Validate(b []byte, cfg ComposableValidateConfig) error {
+	if err := checkMember(k, cfg.Member); err != nil {
+		return err
+	}
+	if cfg.Version != nil {
+		if _, err := k.lin.Schema(*cfg.Version); err != nil {
+			return err
+		}
+	}
+
+	defer lockCUE(k.lin.Runtime().Context())()
+	data, err := vmux.NewJSONCodec(k.MachineName()+".json").Decode(k.lin.Runtime().Context(), b)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Version != nil {
+		sch, _ := k.lin.Schema(*cfg.Version) //nolint:errcheck
+		_, err = validateComposable(k, sch, cfg.Member, data)
+		return err
+	}
+
+	first, _ := k.lin.Schema(k.CurrentVersion()) // we verified at bind of this kind that this schema exists
+	_, firsterr := validateComposable(k, first, cfg.Member, data)
+	if firsterr == nil {
+		return nil
+	}
+	// the error reported is that of the current schema, unless it lacks the
+	// member, in which case it is that of the newest schema with the member
+	reported := firsterr
+	hasMember := memberValue(first, cfg.Member).Exists()
+	for sch := k.lin.First(); sch != nil; sch = sch.Successor() {
+		if sch.Version() == first.Version() || !memberValue(sch, cfg.Member).Exists() {
+			continue
+		}
+		if _, err = validateComposable(k, sch, cfg.Member, data); err == nil {
+			return nil
+		}
+		if !hasMember {
+			reported = err
+		}
+	}
+	return reported
+}
+
+// BindComposable creates a [Composable] from a def, runtime, and opts.
+//
+// If the kind implements a known schema interface (see [SchemaInterfaces]),
+// every schema in its lineage is checked against the interface's contract, and
+// any schemas not satisfying it are returned as a [ContractErrors].
 func BindComposable(rt *thema.Runtime, def Def[ComposableProperties], opts ...thema.BindOption) (Composable, error) {
 	lin, err := def.Some().BindKindLineage(rt, opts...)
 	if err != nil {
 		return nil, err
 	}
+	if err = checkContract(def, lin); err != nil {
+		return nil, err
+	}
 
 	return genericComposable{
 		def: def,
 		lin: lin,
 	}, nil
 }
+
+// BindComposableType creates a [TypedComposable] from a [Composable] kind,
+// providing typed interactions with the Go type T. T must be a struct, or a
+// pointer to one, such as one generated by GoTypesJenny in pkg/codegen.
+//
+// If the kind's lineage is a group, member names the top-level field of the
+// kind's schemas, such as "Options", whose objects are represented by T.
+// Otherwise, member must be empty, and T represents the whole schema.
+//
+// An error is returned if the objects of the kind's current schema are not
+// assignable to T, by the rules of [thema.AssignableTo].
+func BindComposableType[T any](k Composable, member string) (TypedComposable[T], error) {
+	rt := reflect.TypeOf((*T)(nil)).Elem()
+	if rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("type %s is not a struct or a pointer to one", reflect.TypeOf((*T)(nil)).Elem())
+	}
+
+	sch, err := k.Lineage().Schema(k.CurrentVersion())
+	if err != nil {
+		return nil, err
+	}
+	if err = checkMember(k, member); err != nil {
+		return nil, err
+	}
+	at := rt
+	if member != "" {
+		// groupType takes the lock itself, so must be called before taking it
+		if at, err = groupType(k, sch, member, rt); err != nil {
+			return nil, err
+		}
+	}
+	unlock := lockCUE(k.Lineage().Runtime().Context())
+	err = thema.AssignableTo(sch, reflect.New(at).Interface())
+	unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return typedComposable[T]{
+		Composable: k,
+		member:     member,
+	}, nil
+}
+
+// groupType returns a struct type with a field for each member of the group
+// schema sch, where the named member is of type rt and every other is of type
+// any, so that the assignability of rt to the member may be checked by
+// [thema.AssignableTo].
+func groupType(k Composable, sch thema.Schema, member string, rt reflect.Type) (reflect.Type, error) {
+	defer lockCUE(k.Lineage().Runtime().Context())()
+	v := sch.Underlying().LookupPath(cue.MakePath(cue.Str("schema")))
+	if !lookupField(v, member).Exists() {
+		return nil, fmt.Errorf("schema %s of kind %s has no member %s", sch.Version(), k.Name(), member)
+	}
+	return structType(v, map[string]reflect.StructField{member: {Type: rt}})
+}
+
+// typedComposable implements [TypedComposable] on any [Composable].
+type typedComposable[T any] struct {
+	Composable
+	member string
+}
+
+func (k typedComposable[T]) Member() string {
+	return k.member
+}
+
+func (k typedComposable[T]) TypeFromBytes(b []byte) (T, error) {
+	var t T
+	lin := k.Lineage()
+	defer lockCUE(lin.Runtime().Context())()
+	data, err := vmux.NewJSONCodec(k.MachineName()+".json").Decode(lin.Runtime().Context(), b)
+	if err != nil {
+		return t, err
+	}
+
+	sch, err := lin.Schema(k.CurrentVersion())
+	if err != nil {
+		return t, err
+	}
+	v, err := validateComposable(k, sch, k.member, data)
+	if err != nil {
+		return t, err
+	}
+	if err = v.Decode(&t); err != nil {
+		return t, fmt.Errorf("unable to decode into %T: %w", t, err)
+	}
+	return t, nil
+}
+
+// checkMember returns an error if member may not name the objects of the
+// composable kind: if it is empty and the kind's lineage is a group, or if it
+// is not empty and the lineage is not.
+func checkMember(k Composable, member string) error {
+	isGroup := k.Def().Properties.LineageIsGroup
+	switch {
+	case !isGroup && member != "":
+		return fmt.Errorf("lineage of kind %s is not a group, so has no member %s", k.Name(), member)
+	case isGroup && member == "":
+		return fmt.Errorf("lineage of kind %s is a group, so a member must be named", k.Name())
+	}
+	return nil
+}
+
+// memberValue returns the member of the schema sch of a composable kind,
+// which may be optional. An empty member is the whole schema.
+func memberValue(sch thema.Schema, member string) cue.Value {
+	v := sch.Underlying().LookupPath(cue.MakePath(cue.Str("schema")))
+	if member == "" {
+		return v
+	}
+	if mv := v.LookupPath(cue.MakePath(cue.Str(member))); mv.Exists() {
+		return mv
+	}
+	return v.LookupPath(cue.MakePath(cue.Str(member).Optional()))
+}
+
+// validateComposable validates data against the member of the schema sch of
+// the composable kind k, returning data unified with the member's schema. If
+// data is invalid, the returned error is a [*ValidationError].
+//
+// The caller must hold the lock on the kind's cue.Context.
+func validateComposable(k Composable, sch thema.Schema, member string, data cue.Value) (cue.Value, error) {
+	mv := memberValue(sch, member)
+	if !mv.Exists() {
+		return mv, fmt.Errorf("schema %s of kind %s has no member %s", sch.Version(), k.Name(), member)
+	}
+
+	v := mv.Unify(data)
+	err := v.Validate(cue.Concrete(true), cue.All())
+	if err == nil {
+		return v, nil
+	}
+	return v, newValidationError(err, k.Name(), member, sch.Version(), len(mv.Path().Selectors()))
+}
+
+// newValidationError returns a [*ValidationError] describing the CUE errors
+// in err, from validating an object against the member of the schema of the
+// kind. The object is at the path made of the first prefix selectors of the
+// paths of the errors.
+func newValidationError(err error, kind, member string, version thema.SyntacticVersion, prefix int) *ValidationError {
+	verr := &ValidationError{
+		Kind:   kind,
+		Member: member,
+		Schema: version,
+	}
+	byPath := make(map[string]int)
+	for _, e := range errors.Errors(err) {
+		p := fieldPath(e.Path(), prefix)
+		format, args := e.Msg()
+		msg := fmt.Sprintf(format, args...)
+		if strings.HasSuffix(msg, ":") {
+			// summarizes the errors that follow, such as of each branch of
+			// an empty disjunction
+			continue
+		}
+		if strings.HasPrefix(msg, "incomplete value ") {
+			// data is concrete, so only an absent field is incomplete
+			msg = "missing required field of type " + strings.TrimPrefix(msg, "incomplete value ")
+		}
+
+		i, has := byPath[p]
+		if !has {
+			byPath[p] = len(verr.Fields)
+			verr.Fields = append(verr.Fields, FieldError{Path: p, Message: msg})
+		} else if !strings.Contains(verr.Fields[i].Message, msg) {
+			verr.Fields[i].Message += "; " + msg
+		}
+	}
+	return verr
+}
+
+// fieldPath formats the path of a field, given as the selectors of the path
+// from the root of the lineage, relative to the object in which it is a field,
+// whose path is made of the first prefix selectors.
+func fieldPath(sels []string, prefix int) string {
+	if len(sels) < prefix {
+		return ""
+	}
+	var b strings.Builder
+	for _, sel := range sels[prefix:] {
+		if _, err := strconv.Atoi(sel); err == nil {
+			fmt.Fprintf(&b, "[%s]", sel)
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(sel)
+	}
+	return b.String()
+}
+
+// ValidationError describes why an object is invalid against a schema of a
+// kind. It is returned from [Composable.Validate] and [ComposedSchema.Validate].
+type ValidationError struct {
+	// Kind is the name of the kind.
+	Kind string
+	// Member is the member of the schema against which the object was
+	// validated, or empty if it was validated against the whole schema.
+	Member string
+	// Schema is the version of the schema against which the object was
+	// validated.
+	Schema thema.SyntacticVersion
+	// Fields describes each invalid field of the object.
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	obj := e.Kind
+	if e.Member != "" {
+		obj += " " + e.Member
+	}
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.String())
+	}
+	return fmt.Sprintf("%d invalid field(s) in %s against schema %s:\n%s", len(e.Fields), obj, e.Schema, strings.Join(msgs, "\n"))
+}
+
+// FieldError describes a single invalid field of an object.
+type FieldError struct {
+	// Path is the path of the field within the object, such as
+	// "thresholds.steps[0].color", or empty for the object itself.
+	Path string
+	// Message describes why the field is invalid.
+	Message string
+}
+
+func (e FieldError) String() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
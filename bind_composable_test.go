@@ -0,0 +1,225 @@
+package kindsys
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/grafana/thema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposableValidate(t *testing.T) {
+	var panelkind = `
+name: "TestPanelCfg"
+maturity: "experimental"
+schemaInterface: "PanelCfg"
+lineage: name: "testpanelcfg"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		Options: {
+			content: string
+		}
+		Legacy?: {
+			width: int
+		}
+	}
+}, {
+	version: [1, 0]
+	schema: {
+		Options: {
+			text: string
+			mode: "markdown" | "html" | *"markdown"
+			steps?: [...{color: string, value: int & >=0}]
+		}
+		FieldConfig?: {
+			hideFrom?: bool
+		}
+	}
+}]
+lineage: lenses: [{
+	to: [0, 0]
+	from: [1, 0]
+	input: _
+	result: Options: content: input.Options.text
+}, {
+	to: [1, 0]
+	from: [0, 0]
+	input: _
+	result: Options: text: input.Options.content
+}]
+`
+
+	rt := thema.NewRuntime(ctx)
+	def, err := ToDef[ComposableProperties](ctx.CompileString(panelkind))
+	require.NoError(t, err)
+	k, err := BindComposable(rt, def)
+	require.NoError(t, err)
+
+	v00, v10 := thema.SV(0, 0), thema.SV(1, 0)
+
+	err = k.Validate([]byte(`{"text": "hi"}`), ComposableValidateConfig{})
+	require.ErrorContains(t, err, "member must be named")
+	err = k.Validate([]byte(`{"text": "hi"}`), ComposableValidateConfig{Member: "Options", Version: &thema.SyntacticVersion{2, 0}})
+	require.Error(t, err)
+	err = k.Validate([]byte(`{"hideFrom": true}`), ComposableValidateConfig{Member: "FieldConfig", Version: &v00})
+	require.ErrorContains(t, err, "no member FieldConfig")
+
+	// inferred version
+	require.NoError(t, k.Validate([]byte(`{"text": "hi", "mode": "html"}`), ComposableValidateConfig{Member: "Options"}))
+	require.NoError(t, k.Validate([]byte(`{"content": "hi"}`), ComposableValidateConfig{Member: "Options"}))
+	require.NoError(t, k.Validate([]byte(`{"hideFrom": true}`), ComposableValidateConfig{Member: "FieldConfig"}))
+
+	// chosen version
+	require.NoError(t, k.Validate([]byte(`{"content": "hi"}`), ComposableValidateConfig{Member: "Options", Version: &v00}))
+	err = k.Validate([]byte(`{"content": "hi"}`), ComposableValidateConfig{Member: "Options", Version: &v10})
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+	require.Equal(t, "TestPanelCfg", verr.Kind)
+	require.Equal(t, "Options", verr.Member)
+	require.Equal(t, v10, verr.Schema)
+	require.Contains(t, verr.Fields, FieldError{Path: "text", Message: "missing required field of type string"})
+
+	// member only in an older schema, reported against the newest with it
+	require.NoError(t, k.Validate([]byte(`{"width": 3}`), ComposableValidateConfig{Member: "Legacy"}))
+	err = k.Validate([]byte(`{"width": "wide"}`), ComposableValidateConfig{Member: "Legacy"})
+	require.True(t, errors.As(err, &verr))
+	require.Equal(t, "Legacy", verr.Member)
+	require.Equal(t, v00, verr.Schema)
+	require.Equal(t, "width", verr.Fields[0].Path)
+	err = k.Validate([]byte(`{}`), ComposableValidateConfig{Member: "Nope"})
+	require.ErrorContains(t, err, "no member Nope")
+
+	// invalid against every version, reported against the current one
+	err = k.Validate([]byte(`{"text": "hi", "mode": "text", "steps": [{"color": "red", "value": -1}]}`), ComposableValidateConfig{Member: "Options"})
+	require.True(t, errors.As(err, &verr))
+	require.Equal(t, v10, verr.Schema)
+	require.Len(t, verr.Fields, 2)
+	require.Equal(t, "mode", verr.Fields[0].Path)
+	require.Contains(t, verr.Fields[0].Message, `conflicting values "html" and "text"`)
+	require.Contains(t, verr.Fields[0].Message, `conflicting values "markdown" and "text"`)
+	require.Equal(t, FieldError{Path: "steps[0].value", Message: "invalid value -1 (out of bound >=0)"}, verr.Fields[1])
+	require.Contains(t, err.Error(), "2 invalid field(s) in TestPanelCfg Options against schema 1.0:")
+
+	var querykind = `
+name: "TestDataQuery"
+maturity: "experimental"
+schemaInterface: "DataQuery"
+lineage: name: "testdataquery"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		refId: string
+		expr?: string
+	}
+}]
+`
+
+	def, err = ToDef[ComposableProperties](ctx.CompileString(querykind))
+	require.NoError(t, err)
+	k, err = BindComposable(rt, def)
+	require.NoError(t, err)
+
+	require.NoError(t, k.Validate([]byte(`{"refId": "A", "expr": "up"}`), ComposableValidateConfig{}))
+	err = k.Validate([]byte(`{"refId": "A"}`), ComposableValidateConfig{Member: "Options"})
+	require.ErrorContains(t, err, "not a group")
+	err = k.Validate([]byte(`{"refId": 1}`), ComposableValidateConfig{})
+	require.True(t, errors.As(err, &verr))
+	require.Equal(t, "", verr.Member)
+	require.Equal(t, "refId", verr.Fields[0].Path)
+}
+
+func TestBindComposableContract(t *testing.T) {
+	tt := map[string]struct {
+		src    string
+		fields map[string][]FieldError
+	}{
+		"valid": {
+			src: `
+schemaInterface: "DataQuery"
+lineage: schemas: [{version: [0, 0], schema: {refId: string, hide?: bool, expr?: string}}]
+`,
+		},
+		"missing": {
+			src: `
+schemaInterface: "DataQuery"
+lineage: schemas: [{version: [0, 0], schema: {expr: string}}]
+`,
+			fields: map[string][]FieldError{
+				"0.0": {{Path: "refId", Message: "missing field required by the schema interface"}},
+			},
+		},
+		"optional": {
+			src: `
+schemaInterface: "DataQuery"
+lineage: schemas: [{version: [0, 0], schema: {refId?: string}}]
+`,
+			fields: map[string][]FieldError{
+				"0.0": {{Path: "refId", Message: "field is optional, but required by the schema interface"}},
+			},
+		},
+		"incompatible": {
+			src: `
+schemaInterface: "DataQuery"
+lineage: schemas: [{version: [0, 0], schema: {refId: string}}, {version: [0, 1], schema: {refId: string, hide?: int}}]
+`,
+			fields: map[string][]FieldError{
+				"0.1": {{Path: "hide", Message: "int is incompatible with bool, as required by the schema interface"}},
+			},
+		},
+		"group": {
+			src: `
+schemaInterface: "PanelCfg"
+lineage: schemas: [{version: [0, 0], schema: {Options: string, FieldConfig?: {a?: string}}}]
+`,
+			fields: map[string][]FieldError{
+				"0.0": {{Path: "Options", Message: "string is incompatible with {}, as required by the schema interface"}},
+			},
+		},
+	}
+
+	rt := thema.NewRuntime(ctx)
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			def, err := ToDef[ComposableProperties](ctx.CompileString(`
+name: "TestContract"
+maturity: "experimental"
+lineage: name: "testcontract"
+` + tc.src))
+			require.NoError(t, err)
+
+			_, err = BindComposable(rt, def)
+			if tc.fields == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var cerrs ContractErrors
+			require.ErrorAs(t, err, &cerrs)
+			fields := make(map[string][]FieldError)
+			for _, cerr := range cerrs {
+				fields[cerr.Schema.String()] = cerr.Fields
+			}
+			require.Equal(t, tc.fields, fields)
+		})
+	}
+}
+
+func TestBindComposableContract_Loaded(t *testing.T) {
+	// kinds loaded from files embed the framework's Composable through an
+	// import, each in their own context
+	_, err := LoadKind(testKindFS(`package kind
+
+import "github.com/grafana/kindsys"
+
+kindsys.Composable
+name:            "TestContractDataQuery"
+maturity:        "experimental"
+schemaInterface: "DataQuery"
+lineage: name:   "dataquery"
+lineage: schemas: [{version: [0, 0], schema: {hide?: bool}}]
+`), ".")
+	var cerrs ContractErrors
+	require.ErrorAs(t, err, &cerrs)
+	require.Equal(t, []FieldError{{Path: "refId", Message: "missing field required by the schema interface"}}, cerrs[0].Fields)
+}
@@ -12,7 +12,8 @@ type genericCore struct {
 }
 
 func (k genericCore) Validate(b []byte, codec Decoder) error {
-	_, err := bytesToAnyInstance(k, b, codec)
+	defer lockCUE(k.lin.Runtime().Context())()
+	_, _, err := bytesToAnyInstance(k, b, codec)
 	return err
 }
 
@@ -25,12 +26,13 @@ func (k genericCore) Group() string {
 }
 
 func (k genericCore) FromBytes(b []byte, codec Decoder) (*UnstructuredResource, error) {
-	inst, err := bytesToAnyInstance(k, b, codec)
+	defer lockCUE(k.lin.Runtime().Context())()
+	inst, gb, err := bytesToAnyInstance(k, b, codec)
 	if err != nil {
 		return nil, err
 	}
 	// we have a valid instance! decode into unstructured
-	return grafanaShapeToUnstructured(k, inst)
+	return grafanaShapeToUnstructured(k, inst, gb)
 }
 
 var _ Core = genericCore{}
@@ -72,8 +74,31 @@ func BindCore(rt *thema.Runtime, def Def[CoreProperties], opts ...thema.BindOpti
 	}, nil
 }
 
-// TODO docs
+// BindCoreResource creates a [TypedCore] from a [Core] kind, providing
+// typed interactions with the resource type R. R must be a pointer to a struct,
+// such as one generated by GoResourceJenny in pkg/codegen, with fields named
+// "spec" and "customMetadata" in JSON, and one named for each of the kind's
+// subresources.
+//
+// An error is returned if the objects of the kind's current schema are not
+// assignable to R, by the rules of [thema.AssignableTo].
 func BindCoreResource[R Resource](k Core) (TypedCore[R], error) {
-	// TODO implement me
-	panic("implement me")
+	if err := checkResource[R](k); err != nil {
+		return nil, err
+	}
+	return typedCore[R]{Core: k}, nil
+}
+
+// typedCore implements [TypedCore] on any [Core].
+type typedCore[R Resource] struct {
+	Core
+}
+
+func (k typedCore[R]) TypeFromBytes(b []byte, codec Decoder) (R, error) {
+	u, err := k.FromBytes(b, codec)
+	if err != nil {
+		var r R
+		return r, err
+	}
+	return unstructuredToTyped[R](u)
 }
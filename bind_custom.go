@@ -12,19 +12,19 @@ type genericCustom struct {
 }
 
 func (k genericCustom) FromBytes(b []byte, codec Decoder) (*UnstructuredResource, error) {
-	inst, err := bytesToAnyInstance(k, b, codec)
+	defer lockCUE(k.lin.Runtime().Context())()
+	inst, gb, err := bytesToAnyInstance(k, b, codec)
 	if err != nil {
 		return nil, err
 	}
 	// we have a valid instance! decode into unstructured
-	// TODO implement me
-	_ = inst
-	panic("implement me")
+	return grafanaShapeToUnstructured(k, inst, gb)
 }
 
 func (k genericCustom) Validate(b []byte, codec Decoder) error {
-	// TODO implement me
-	panic("implement me")
+	defer lockCUE(k.lin.Runtime().Context())()
+	_, _, err := bytesToAnyInstance(k, b, codec)
+	return err
 }
 
 func (k genericCustom) CurrentVersion() thema.SyntacticVersion {
@@ -82,8 +82,31 @@ func BindCustom(rt *thema.Runtime, def Def[CustomProperties], opts ...thema.Bind
 	}, nil
 }
 
-// TODO docs
+// BindCustomResource creates a [TypedCustom] from a [Custom] kind, providing
+// typed interactions with the resource type R. R must be a pointer to a struct,
+// such as one generated by GoResourceJenny in pkg/codegen, with fields named
+// "spec" and "customMetadata" in JSON, and one named for each of the kind's
+// subresources.
+//
+// An error is returned if the objects of the kind's current schema are not
+// assignable to R, by the rules of [thema.AssignableTo].
 func BindCustomResource[R Resource](k Custom) (TypedCustom[R], error) {
-	// TODO implement me
-	panic("implement me")
+	if err := checkResource[R](k); err != nil {
+		return nil, err
+	}
+	return typedCustom[R]{Custom: k}, nil
+}
+
+// typedCustom implements [TypedCustom] on any [Custom].
+type typedCustom[R Resource] struct {
+	Custom
+}
+
+func (k typedCustom[R]) TypeFromBytes(b []byte, codec Decoder) (R, error) {
+	u, err := k.FromBytes(b, codec)
+	if err != nil {
+		var r R
+		return r, err
+	}
+	return unstructuredToTyped[R](u)
 }
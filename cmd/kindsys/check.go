@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+
+	"github.com/grafana/kindsys"
+)
+
+func runCheck(c *cli, args []string) int {
+	fl := c.flags("check", "[-shape kubernetes|grafana] [-format json|yaml] <kind-dir> <resource-file>...")
+	shape := fl.String("shape", shapeKubernetes, "shape of the resource files, kubernetes or grafana")
+	format := fl.String("format", "", "format of the resource files, json or yaml (default inferred from each file's extension)")
+	if !c.parse(fl, args, 2, -1) {
+		return exitUsage
+	}
+	files := fl.Args()[1:]
+	codecs := make([]codec, len(files))
+	for i, file := range files {
+		var err error
+		if codecs[i], err = codecFor(*shape, formatOf(file, *format)); err != nil {
+			c.errorf("kindsys check: %s", err)
+			return exitUsage
+		}
+	}
+
+	k, ok := c.loadKind(fl.Arg(0))
+	if !ok {
+		return exitFail
+	}
+	rk, ok := k.(kindsys.ResourceKind)
+	if !ok {
+		c.errorf("%s: %s is a %s kind, which has no resources to check", fl.Arg(0), k.Name(), category(k))
+		return exitFail
+	}
+
+	code := exitOK
+	for i, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			c.errorf("%s", err)
+			code = exitFail
+			continue
+		}
+		if err = rk.Validate(b, codecs[i].dec); err != nil {
+			c.errorf("%s: %s", file, trimPos(fl.Arg(0), err.Error()))
+			code = exitFail
+			continue
+		}
+		c.outf("ok\t%s", file)
+	}
+	return code
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/grafana/kindsys"
+	"github.com/grafana/kindsys/encoding"
+)
+
+// Shapes and formats of serialized resources.
+const (
+	shapeKubernetes = "kubernetes"
+	shapeGrafana    = "grafana"
+
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+type codec struct {
+	dec kindsys.Decoder
+	enc kindsys.Encoder
+}
+
+var codecs = map[[2]string]codec{
+	{shapeKubernetes, formatJSON}: {&encoding.KubernetesJSONDecoder{}, &encoding.KubernetesJSONEncoder{}},
+	{shapeKubernetes, formatYAML}: {&encoding.KubernetesYAMLDecoder{}, &encoding.KubernetesYAMLEncoder{}},
+	{shapeGrafana, formatJSON}:    {&encoding.GrafanaJSONDecoder{}, &encoding.GrafanaJSONEncoder{}},
+	{shapeGrafana, formatYAML}:    {&encoding.GrafanaYAMLDecoder{}, &encoding.GrafanaYAMLEncoder{}},
+}
+
+// codecFor returns the codec for resources in the provided shape and format.
+func codecFor(shape, format string) (codec, error) {
+	switch shape {
+	case shapeKubernetes, shapeGrafana:
+	default:
+		return codec{}, fmt.Errorf("unknown shape %q, expected %s or %s", shape, shapeKubernetes, shapeGrafana)
+	}
+	c, has := codecs[[2]string{shape, format}]
+	if !has {
+		return codec{}, fmt.Errorf("unknown format %q, expected %s or %s", format, formatJSON, formatYAML)
+	}
+	return c, nil
+}
+
+// formatOf returns format if it is not empty, or else the format indicated
+// by the file's extension.
+func formatOf(file, format string) string {
+	if format != "" {
+		return format
+	}
+	switch filepath.Ext(file) {
+	case ".yaml", ".yml":
+		return formatYAML
+	default:
+		return formatJSON
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	"github.com/grafana/kindsys"
+	"github.com/grafana/thema"
+)
+
+func runConvert(c *cli, args []string) int {
+	fl := c.flags("convert", "[-from shape] [-from-format format] [-to shape] [-to-format format] [-version x.y] <kind-dir> <resource-file>")
+	from := fl.String("from", shapeKubernetes, "shape of the resource file, kubernetes or grafana")
+	fromFormat := fl.String("from-format", "", "format of the resource file, json or yaml (default inferred from the file's extension)")
+	to := fl.String("to", "", "shape to which to convert, kubernetes or grafana (default the shape of the resource file)")
+	toFormat := fl.String("to-format", "", "format to which to convert, json or yaml (default the format of the resource file)")
+	version := fl.String("version", "", "version of the kind's schema to which to translate the resource (default no translation)")
+	if !c.parse(fl, args, 2, 2) {
+		return exitUsage
+	}
+	file := fl.Arg(1)
+	*fromFormat = formatOf(file, *fromFormat)
+	if *to == "" {
+		*to = *from
+	}
+	if *toFormat == "" {
+		*toFormat = *fromFormat
+	}
+
+	cfg := kindsys.ConvertConfig{}
+	in, err := codecFor(*from, *fromFormat)
+	if err == nil {
+		var out codec
+		out, err = codecFor(*to, *toFormat)
+		cfg.Decoder, cfg.Encoder = in.dec, out.enc
+	}
+	if err == nil && *version != "" {
+		var v thema.SyntacticVersion
+		v, err = thema.ParseSyntacticVersion(*version)
+		cfg.Version = &v
+	}
+	if err != nil {
+		c.errorf("kindsys convert: %s", err)
+		return exitUsage
+	}
+
+	k, ok := c.loadKind(fl.Arg(0))
+	if !ok {
+		return exitFail
+	}
+	rk, ok := k.(kindsys.ResourceKind)
+	if !ok {
+		c.errorf("%s: %s is a %s kind, which has no resources to convert", fl.Arg(0), k.Name(), category(k))
+		return exitFail
+	}
+
+	b, err := os.ReadFile(file)
+	if err != nil {
+		c.errorf("%s", err)
+		return exitFail
+	}
+	b, lac, err := kindsys.Convert(rk, b, cfg)
+	if err != nil {
+		c.errorf("%s: %s", file, trimPos(fl.Arg(0), err.Error()))
+		return exitFail
+	}
+	if lac != nil {
+		for _, l := range lac.AsList() {
+			c.errorf("%s: translation lacuna: %s", file, l.Message)
+		}
+	}
+
+	if *toFormat == formatJSON {
+		var buf bytes.Buffer
+		if err = json.Indent(&buf, b, "", "  "); err == nil {
+			b = append(buf.Bytes(), '\n')
+		}
+	}
+	c.stdout.Write(b) //nolint:errcheck
+	return exitOK
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/codejen"
+	"github.com/grafana/kindsys/pkg/codegen"
+)
+
+func runCRD(c *cli, args []string) int {
+	fl := c.flags("crd", "[-out dir] <dir>...")
+	out := fl.String("out", "", "directory to which to write one file per CRD (default writes all CRDs to stdout as a YAML stream)")
+	if !c.parse(fl, args, 1, -1) {
+		return exitUsage
+	}
+
+	code := exitOK
+	jfs := codejen.NewFS()
+	for _, dir := range fl.Args() {
+		kinds, ok := c.loadKinds(dir)
+		if !ok {
+			code = exitFail
+		}
+		for _, k := range kinds {
+			f, err := codegen.CRDJenny{}.Generate(k)
+			if err != nil {
+				c.errorf("%s: %s: %s", dir, k.Name(), err)
+				code = exitFail
+				continue
+			}
+			if f == nil {
+				// not a CRD
+				continue
+			}
+			if *out == "" {
+				fmt.Fprintf(c.stdout, "---\n%s", f.Data)
+				continue
+			}
+			if err = jfs.Add(*f); err != nil {
+				c.errorf("%s", err)
+				code = exitFail
+			}
+		}
+	}
+
+	if *out == "" || code != exitOK {
+		return code
+	}
+	if err := jfs.Write(context.Background(), *out); err != nil {
+		c.errorf("%s", err)
+		return exitFail
+	}
+	return exitOK
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/grafana/kindsys/pkg/compat"
+	"github.com/grafana/thema"
+)
+
+func runDiff(c *cli, args []string) int {
+	fl := c.flags("diff", "[-from version] [-to version] <kind-dir>")
+	from := fl.String("from", "", "version of the schema to diff from (default the first schema)")
+	to := fl.String("to", "", "version of the schema to diff to (default the latest schema)")
+	if !c.parse(fl, args, 1, 1) {
+		return exitUsage
+	}
+
+	k, ok := c.loadKind(fl.Arg(0))
+	if !ok {
+		return exitFail
+	}
+
+	if *from == "" && *to == "" {
+		// every consecutive pair of schemas
+		r, _ := compat.Check(k) //nolint:errcheck
+		c.outf("%s", r)
+		if len(r.Violations()) > 0 {
+			return exitFail
+		}
+		return exitOK
+	}
+
+	lin := k.Lineage()
+	fsch, tsch := lin.First(), lin.Latest()
+	for _, v := range []struct {
+		arg string
+		sch *thema.Schema
+	}{{*from, &fsch}, {*to, &tsch}} {
+		if v.arg == "" {
+			continue
+		}
+		sv, err := thema.ParseSyntacticVersion(v.arg)
+		if err != nil {
+			c.errorf("kindsys diff: %s", err)
+			return exitUsage
+		}
+		if *v.sch, err = lin.Schema(sv); err != nil {
+			c.errorf("%s: %s", fl.Arg(0), err)
+			return exitFail
+		}
+	}
+
+	step := compat.DiffSchemas(fsch, tsch)
+	c.outf("%s", compat.Report{Kind: k.Name(), Steps: []compat.Step{step}})
+	if !step.IsMajor() && len(step.Breaking()) > 0 {
+		return exitFail
+	}
+	return exitOK
+}
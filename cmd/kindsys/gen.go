@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grafana/kindsys"
+	"github.com/grafana/kindsys/pkg/codegen"
+)
+
+// generators maps the name of each generator available to the gen command to
+// the configuration of its jenny. Each generator's output is written to a
+// subdirectory of the same name.
+var generators = map[string]codegen.JennyConfig{
+	"go":         {Jenny: "GoTypesJenny", Output: "go"},
+	"ts":         {Jenny: "TSTypesJenny", Output: "ts"},
+	"jsonschema": {Jenny: "JsonSchemaJenny", Output: "jsonschema"},
+	"crd":        {Jenny: "CRDJenny", Output: "crd"},
+}
+
+func generatorNames() []string {
+	names := make([]string, 0, len(generators))
+	for name := range generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func runGen(c *cli, args []string) int {
+	fl := c.flags("gen", "[-verify] [-out dir] [-gen go,ts,...] <dir>...\n       kindsys gen [-verify] -config file")
+	out := fl.String("out", ".", "directory to which to write generated files")
+	gens := fl.String("gen", "go,ts", "comma-separated generators to run, of "+strings.Join(generatorNames(), ", "))
+	config := fl.String("config", "", "codegen config `file`, in CUE, YAML or JSON, declaring the kinds and generators; paths in it are relative to its directory")
+	verify := fl.Bool("verify", false, "check that generated files are up to date, rather than writing them")
+	if err := fl.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	// base is the directory to which generated file paths are relative, and
+	// dirs those from which kinds are loaded.
+	var cfg *codegen.Config
+	var base string
+	var dirs []string
+	if *config != "" {
+		if fl.NArg() != 0 {
+			fl.Usage()
+			return exitUsage
+		}
+		var err error
+		if cfg, err = codegen.LoadConfig(*config); err != nil {
+			c.errorf("%s", err)
+			return exitUsage
+		}
+		base = filepath.Dir(*config)
+		for _, kdir := range cfg.Kinds {
+			dirs = append(dirs, filepath.Join(base, filepath.FromSlash(kdir)))
+		}
+	} else {
+		if fl.NArg() == 0 {
+			fl.Usage()
+			return exitUsage
+		}
+		cfg = &codegen.Config{}
+		for _, name := range splitList(*gens) {
+			jc, has := generators[name]
+			if !has {
+				c.errorf("kindsys gen: unknown generator %q", name)
+				return exitUsage
+			}
+			cfg.Jennies = append(cfg.Jennies, jc)
+		}
+		base, dirs = *out, fl.Args()
+	}
+
+	code := exitOK
+	var kinds []kindsys.Kind
+	for _, dir := range dirs {
+		dkinds, ok := c.loadKinds(dir)
+		if !ok {
+			code = exitFail
+		}
+		kinds = append(kinds, dkinds...)
+	}
+	if code != exitOK {
+		return code
+	}
+
+	jfs, err := cfg.Generate(kinds...)
+	if err != nil {
+		c.errorf("%s", err)
+		return exitFail
+	}
+	if *verify {
+		if err := jfs.Verify(context.Background(), base); err != nil {
+			c.errorf("%s", err)
+			return exitFail
+		}
+		return exitOK
+	}
+	if err := jfs.Write(context.Background(), base); err != nil {
+		c.errorf("%s", err)
+		return exitFail
+	}
+	for _, f := range jfs.AsFiles() {
+		c.outf("%s", f.RelativePath)
+	}
+	return exitOK
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"github.com/grafana/kindsys/pkg/lint"
+)
+
+func runLint(c *cli, args []string) int {
+	fl := c.flags("lint", "[-disable rule,...] [-rules] <dir>...")
+	disable := fl.String("disable", "", "comma-separated lint rules to disable")
+	list := fl.Bool("rules", false, "list the available lint rules and exit")
+	if err := fl.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	l := lint.New()
+	if *list {
+		for _, r := range l.Rules() {
+			c.outf("%s\t%s", r.Name(), r.Description())
+		}
+		return exitOK
+	}
+	if fl.NArg() < 1 {
+		fl.Usage()
+		return exitUsage
+	}
+	if err := l.Disable(splitList(*disable)...); err != nil {
+		c.errorf("kindsys lint: %s", err)
+		return exitUsage
+	}
+
+	code := exitOK
+	for _, dir := range fl.Args() {
+		kinds, ok := c.loadKinds(dir)
+		if !ok {
+			code = exitFail
+		}
+		for _, k := range kinds {
+			for _, f := range l.Lint(k) {
+				c.outf("%s", trimPos(dir, f.String()))
+				code = exitFail
+			}
+		}
+	}
+	return code
+}
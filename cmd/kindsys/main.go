@@ -0,0 +1,188 @@
+// Command kindsys loads, checks and generates code from kind definitions.
+//
+// Usage:
+//
+//	kindsys <command> [flags] [arguments]
+//
+// The commands are:
+//
+//	validate  load and bind every kind definition under directories
+//	check     validate resource files against a kind
+//	convert   convert a resource file between shapes, formats and versions
+//	gen       generate code for kinds
+//	crd       generate Kubernetes CustomResourceDefinitions for kinds
+//	lint      check kinds for naming and schema hygiene
+//	diff      report schema changes between versions of a kind
+//
+// Run "kindsys <command> -h" for the flags and arguments of a command.
+//
+// Every command is intended for use in CI: it exits 0 on success, 1 if any
+// kind or resource fails, and 2 if it was invoked incorrectly.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/grafana/kindsys"
+)
+
+const (
+	exitOK    = 0
+	exitFail  = 1
+	exitUsage = 2
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(c *cli, args []string) int
+}
+
+var commands = []command{
+	{"validate", "load and bind every kind definition under directories", runValidate},
+	{"check", "validate resource files against a kind", runCheck},
+	{"convert", "convert a resource file between shapes, formats and versions", runConvert},
+	{"gen", "generate code for kinds", runGen},
+	{"crd", "generate Kubernetes CustomResourceDefinitions for kinds", runCRD},
+	{"lint", "check kinds for naming and schema hygiene", runLint},
+	{"diff", "report schema changes between versions of a kind", runDiff},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command named in args[0], returning the exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	c := &cli{stdout: stdout, stderr: stderr}
+	if len(args) == 0 {
+		c.usage()
+		return exitUsage
+	}
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		c.usage()
+		return exitOK
+	}
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(c, args[1:])
+		}
+	}
+	fmt.Fprintf(stderr, "kindsys: unknown command %q\n", args[0])
+	c.usage()
+	return exitUsage
+}
+
+// cli holds the output streams shared by all commands.
+type cli struct {
+	stdout, stderr io.Writer
+}
+
+func (c *cli) usage() {
+	fmt.Fprintln(c.stderr, "Usage: kindsys <command> [flags] [arguments]\n\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(c.stderr, "  %-9s %s\n", cmd.name, cmd.summary)
+	}
+}
+
+// flags returns a FlagSet for the named command, printing the provided
+// argument synopsis in its usage.
+func (c *cli) flags(name, synopsis string) *flag.FlagSet {
+	fl := flag.NewFlagSet(name, flag.ContinueOnError)
+	fl.SetOutput(c.stderr)
+	fl.Usage = func() {
+		fmt.Fprintf(c.stderr, "Usage: kindsys %s %s\n", name, synopsis)
+		fl.PrintDefaults()
+	}
+	return fl
+}
+
+// parse parses args into fl, reporting whether parsing succeeded and left
+// at least min positional arguments, and no more than max if max is not
+// negative.
+func (c *cli) parse(fl *flag.FlagSet, args []string, min, max int) bool {
+	if err := fl.Parse(args); err != nil {
+		return false
+	}
+	if n := fl.NArg(); n < min || (max >= 0 && n > max) {
+		fl.Usage()
+		return false
+	}
+	return true
+}
+
+func (c *cli) outf(format string, args ...any) {
+	fmt.Fprintf(c.stdout, format+"\n", args...)
+}
+
+func (c *cli) errorf(format string, args ...any) {
+	fmt.Fprintf(c.stderr, format+"\n", args...)
+}
+
+// loadKinds loads and binds every kind definition under dir, printing any
+// failures. It reports whether all kinds loaded successfully, and at least
+// one was found.
+func (c *cli) loadKinds(dir string) ([]kindsys.Kind, bool) {
+	kinds, err := kindsys.LoadKinds(os.DirFS(dir), ".", kindsys.LoadKindsConfig{})
+	if err != nil {
+		var lerrs kindsys.LoadErrors
+		if !errors.As(err, &lerrs) {
+			c.errorf("%s: %s", dir, err)
+			return kinds, false
+		}
+		for _, lerr := range lerrs {
+			c.errorf("%s: %s", filepath.Join(dir, lerr.Path), trimPos(dir, lerr.Err.Error()))
+		}
+		return kinds, false
+	}
+	if len(kinds) == 0 {
+		c.errorf("%s: no kind definitions found", dir)
+		return nil, false
+	}
+	return kinds, true
+}
+
+// loadKind loads and binds the single kind definition in dir, printing any
+// failure.
+func (c *cli) loadKind(dir string) (kindsys.Kind, bool) {
+	k, err := kindsys.LoadKind(os.DirFS(dir), ".")
+	if err != nil {
+		c.errorf("%s: %s", dir, trimPos(dir, err.Error()))
+		return nil, false
+	}
+	return k, true
+}
+
+var overlayPos = regexp.MustCompile(`/github\.com/grafana/kindsys/([^\s:]+)`)
+
+// trimPos rewrites the positions in s of CUE files in dir, which are reported
+// rooted at the kindsys module path, to be relative to the working directory.
+// Positions in the kindsys framework itself are left as they are.
+func trimPos(dir, s string) string {
+	return overlayPos.ReplaceAllStringFunc(s, func(pos string) string {
+		file := filepath.Join(dir, filepath.FromSlash(overlayPos.FindStringSubmatch(pos)[1]))
+		if _, err := os.Stat(file); err != nil {
+			return pos
+		}
+		return filepath.ToSlash(file)
+	})
+}
+
+// splitList splits a comma-separated flag value, ignoring empty elements.
+func splitList(s string) []string {
+	var l []string
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			l = append(l, e)
+		}
+	}
+	return l
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// runTest runs the kindsys command with the provided arguments, returning its
+// exit code, stdout and stderr.
+func runTest(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestUsage(t *testing.T) {
+	code, _, stderr := runTest()
+	require.Equal(t, exitUsage, code)
+	require.Contains(t, stderr, "Usage: kindsys <command>")
+
+	code, _, stderr = runTest("frobnicate")
+	require.Equal(t, exitUsage, code)
+	require.Contains(t, stderr, `unknown command "frobnicate"`)
+
+	code, _, stderr = runTest("check", "testdata/kinds/folder")
+	require.Equal(t, exitUsage, code)
+	require.Contains(t, stderr, "Usage: kindsys check")
+
+	code, _, _ = runTest("gen", "-gen", "cobol", "testdata/kinds")
+	require.Equal(t, exitUsage, code)
+}
+
+func TestValidate(t *testing.T) {
+	code, stdout, stderr := runTest("validate", "testdata/kinds")
+	require.Equal(t, exitOK, code, stderr)
+	require.Equal(t, "ok\tFolder\tcore\tmerged\t0.1\nok\tThing\tcustom\tmerged\t0.0\n", stdout)
+
+	code, _, stderr = runTest("validate", "testdata/broken")
+	require.Equal(t, exitFail, code)
+	require.Contains(t, stderr, "testdata/broken/bad: ")
+	require.Contains(t, stderr, `name: invalid value "bad"`)
+
+	code, _, stderr = runTest("validate", "testdata/resources")
+	require.Equal(t, exitFail, code)
+	require.Contains(t, stderr, "no kind definitions found")
+}
+
+func TestCheck(t *testing.T) {
+	code, stdout, stderr := runTest("check", "testdata/kinds/folder", "testdata/resources/folder.json", "testdata/resources/folder.yaml")
+	require.Equal(t, exitOK, code, stderr)
+	require.Equal(t, "ok\ttestdata/resources/folder.json\nok\ttestdata/resources/folder.yaml\n", stdout)
+
+	code, stdout, stderr = runTest("check", "testdata/kinds/folder", "testdata/resources/folder.json", "testdata/resources/invalid.yaml")
+	require.Equal(t, exitFail, code)
+	require.Equal(t, "ok\ttestdata/resources/folder.json\n", stdout)
+	require.Contains(t, stderr, "testdata/resources/invalid.yaml: ")
+	// positioned in the kind definition
+	require.Contains(t, stderr, "testdata/kinds/folder/folder.cue:37:14")
+
+	code, _, _ = runTest("check", "-format", "json", "testdata/kinds/folder", "testdata/resources/folder.yaml")
+	require.Equal(t, exitFail, code)
+
+	code, _, _ = runTest("check", "-shape", "grafana", "testdata/kinds/folder", "testdata/resources/folder.yaml")
+	require.Equal(t, exitFail, code)
+}
+
+func TestGen(t *testing.T) {
+	out := t.TempDir()
+	code, stdout, stderr := runTest("gen", "-out", out, "-gen", "go,crd", "testdata/kinds")
+	require.Equal(t, exitOK, code, stderr)
+	require.Equal(t, []string{
+		"crd/folder.crd.yaml",
+		"crd/thing.crd.yaml",
+		"go/folder/x/folder_types_gen.go",
+		"go/thing/x/thing_types_gen.go",
+	}, strings.Fields(stdout))
+	for _, f := range strings.Fields(stdout) {
+		require.FileExists(t, filepath.Join(out, f))
+	}
+
+	code, _, stderr = runTest("gen", "-verify", "-out", out, "-gen", "go,crd", "testdata/kinds")
+	require.Equal(t, exitOK, code, stderr)
+	require.NoError(t, os.WriteFile(filepath.Join(out, "crd/thing.crd.yaml"), nil, 0644))
+	code, _, stderr = runTest("gen", "-verify", "-out", out, "-gen", "go,crd", "testdata/kinds")
+	require.Equal(t, exitFail, code)
+	require.Contains(t, stderr, "thing.crd.yaml would have changed")
+}
+
+func TestGenConfig(t *testing.T) {
+	dir := t.TempDir()
+	src, err := os.ReadFile("testdata/kinds/thing/thing.cue")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "kinds/thing"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "kinds/thing/thing.cue"), src, 0644))
+	config := filepath.Join(dir, "gen.yaml")
+	require.NoError(t, os.WriteFile(config, []byte(`kinds: [kinds]
+jennies:
+  - {jenny: TSTypesJenny, layout: LatestJenny, output: ts}
+  - {jenny: CRDJenny, categories: [core], output: crd}
+`), 0644))
+
+	code, _, stderr := runTest("gen", "-verify", "-config", config)
+	require.Equal(t, exitFail, code)
+	require.Contains(t, stderr, "should exist, but does not")
+
+	code, stdout, stderr := runTest("gen", "-config", config)
+	require.Equal(t, exitOK, code, stderr)
+	require.Equal(t, "ts/thing/thing_types.gen.ts\n", stdout)
+
+	code, _, stderr = runTest("gen", "-verify", "-config", config)
+	require.Equal(t, exitOK, code, stderr)
+
+	code, _, _ = runTest("gen", "-config", config, "testdata/kinds")
+	require.Equal(t, exitUsage, code)
+}
+
+func TestCRD(t *testing.T) {
+	code, stdout, stderr := runTest("crd", "testdata/kinds")
+	require.Equal(t, exitOK, code, stderr)
+	require.Equal(t, 2, strings.Count(stdout, "---\n"))
+	require.Contains(t, stdout, "name: folders.folder.core.grafana.com\n")
+	require.Contains(t, stdout, "name: things.things.ext.grafana.com\n")
+
+	out := t.TempDir()
+	code, _, stderr = runTest("crd", "-out", out, "testdata/kinds/folder")
+	require.Equal(t, exitOK, code, stderr)
+	b, err := os.ReadFile(filepath.Join(out, "folder.crd.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(b), "kind: CustomResourceDefinition\n")
+}
+
+func TestLint(t *testing.T) {
+	code, stdout, _ := runTest("lint", "testdata/kinds")
+	require.Equal(t, exitFail, code)
+	require.Equal(t, `testdata/kinds/thing/thing.cue:17:4: Thing@0.0 spec.ownerID: field "ownerID" should be named "ownerId" (lowercase-id)`+"\n", stdout)
+
+	code, stdout, stderr := runTest("lint", "-disable", "lowercase-id", "testdata/kinds")
+	require.Equal(t, exitOK, code, stderr)
+	require.Empty(t, stdout)
+
+	code, _, stderr = runTest("lint", "-disable", "no-such-rule", "testdata/kinds")
+	require.Equal(t, exitUsage, code)
+	require.Contains(t, stderr, `unknown lint rule "no-such-rule"`)
+}
+
+func TestDiff(t *testing.T) {
+	code, stdout, stderr := runTest("diff", "testdata/kinds/folder")
+	require.Equal(t, exitOK, code, stderr)
+	require.Equal(t, "Folder:\n\t0.0 -> 0.1\n\t\tspec.parent: field added (optional)\n", stdout)
+
+	code, stdout, _ = runTest("diff", "-from", "0.1", "-to", "0.0", "testdata/kinds/folder")
+	require.Equal(t, exitFail, code)
+	require.Contains(t, stdout, "spec.parent: field removed [breaking]")
+
+	code, _, stderr = runTest("diff", "-from", "3.0", "testdata/kinds/folder")
+	require.Equal(t, exitFail, code)
+	require.NotEmpty(t, stderr)
+}
+
+func TestConvert(t *testing.T) {
+	code, stdout, stderr := runTest("convert", "-to", "grafana", "-to-format", "yaml", "testdata/kinds/folder", "testdata/resources/folder.json")
+	require.Equal(t, exitOK, code, stderr)
+	require.Contains(t, stdout, "group: folder.core.grafana.com\n")
+	require.Contains(t, stdout, "apiVersion: v0-1\n")
+	require.Contains(t, stdout, "  parent: root\n")
+
+	code, stdout, stderr = runTest("convert", "-version", "0.1", "testdata/kinds/folder", "testdata/resources/folder_v0-0.yaml")
+	require.Equal(t, exitOK, code, stderr)
+	require.Contains(t, stdout, "apiVersion: folder.core.grafana.com/v0-1\n")
+	require.Contains(t, stdout, "  name: general\n")
+
+	code, stdout, stderr = runTest("convert", "-to-format", "json", "testdata/kinds/folder", "testdata/resources/folder.yaml")
+	require.Equal(t, exitOK, code, stderr)
+	require.Contains(t, stdout, "\n  \"apiVersion\": \"folder.core.grafana.com/v0-1\",\n")
+
+	code, _, stderr = runTest("convert", "-version", "9.0", "testdata/kinds/folder", "testdata/resources/folder.yaml")
+	require.Equal(t, exitFail, code)
+	require.Contains(t, stderr, "no schema with version 9.0")
+
+	code, _, stderr = runTest("convert", "testdata/kinds/folder", "testdata/resources/invalid.yaml")
+	require.Equal(t, exitFail, code)
+	require.Contains(t, stderr, "testdata/resources/invalid.yaml: ")
+
+	code, _, _ = runTest("convert", "-to", "openshift", "testdata/kinds/folder", "testdata/resources/folder.yaml")
+	require.Equal(t, exitUsage, code)
+}
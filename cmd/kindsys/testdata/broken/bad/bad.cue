@@ -0,0 +1,11 @@
+package kind
+
+import "github.com/grafana/kindsys"
+
+kindsys.Core
+name:        "bad"
+description: "Kind names must be PascalCase."
+lineage: schemas: [{
+	version: [0, 0]
+	schema: spec: title: string
+}]
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"github.com/grafana/kindsys"
+	"github.com/grafana/kindsys/pkg/policy"
+)
+
+func runValidate(c *cli, args []string) int {
+	fl := c.flags("validate", "[-policy=false] <dir>...")
+	usePolicy := fl.Bool("policy", true, "also check kinds against the maturity policy rules")
+	if !c.parse(fl, args, 1, -1) {
+		return exitUsage
+	}
+
+	code := exitOK
+	for _, dir := range fl.Args() {
+		kinds, ok := c.loadKinds(dir)
+		if !ok {
+			code = exitFail
+		}
+		for _, k := range kinds {
+			if *usePolicy {
+				if vs := policy.Default().Evaluate(k); len(vs) > 0 {
+					for _, v := range vs {
+						c.errorf("%s: %s", dir, v)
+					}
+					code = exitFail
+					continue
+				}
+			}
+			c.outf("ok\t%s\t%s\t%s\t%s", k.Name(), category(k), k.Maturity(), k.Lineage().Latest().Version())
+		}
+	}
+	return code
+}
+
+// category returns the name of the kind's category.
+func category(k kindsys.Kind) string {
+	switch k.(type) {
+	case kindsys.Core:
+		return "core"
+	case kindsys.Custom:
+		return "custom"
+	case kindsys.Composable:
+		return "composable"
+	default:
+		return "unknown"
+	}
+}
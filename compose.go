@@ -0,0 +1,331 @@
+package kindsys
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/token"
+	"github.com/grafana/thema"
+	"github.com/grafana/thema/vmux"
+)
+
+// Slot is a position in the schema of a [Core] or [Custom] kind at which
+// objects described by [Composable] kinds implementing a schema interface are
+// composed. For example, the options of each panel in a dashboard are
+// described by the PanelCfg composable kind of the panel's plugin:
+//
+//	kindsys.Slot{
+//		Path:          "spec.panels[]",
+//		Field:         "options",
+//		Discriminator: "type",
+//		Interface:     "PanelCfg",
+//		Member:        "Options",
+//		Implementations: map[string]kindsys.Composable{
+//			"text":       textPanelCfg,
+//			"timeseries": timeseriesPanelCfg,
+//		},
+//	}
+type Slot struct {
+	// Path is the dot-separated path, within the kind's schema, of the objects
+	// containing the slot. A field name suffixed with "[]" denotes each element
+	// of the list in that field.
+	Path string
+
+	// Field is the field, within the objects at Path, in which the composed
+	// objects are placed. If empty, the composed objects are the objects at
+	// Path themselves.
+	Field string
+
+	// Discriminator is the dot-separated path, within the objects at Path, of
+	// the string field whose value selects the implementation describing the
+	// composed object, such as a plugin type.
+	Discriminator string
+
+	// Interface is the name of the schema interface the implementations
+	// implement.
+	Interface string
+
+	// Member is the member of the implementations' schemas describing the
+	// composed objects, such as "Options". It is required if the schema
+	// interface is a group (see [SchemaInterface.IsGroup]), and must be empty
+	// otherwise.
+	Member string
+
+	// Implementations are the composable kinds describing the composed
+	// objects, keyed by the discriminator value selecting each.
+	Implementations map[string]Composable
+}
+
+// ComposedSchema is the current schema of a [Core] or [Custom] kind, composed
+// with the schemas of [Composable] kinds at its slots. It is created by
+// [Compose].
+type ComposedSchema struct {
+	kind  ResourceKind
+	sch   thema.Schema
+	v     cue.Value
+	slots []Slot
+}
+
+// Compose composes the current schema of the kind k with the current schemas
+// of the implementations of each slot.
+//
+// At each slot, the objects at the slot's path must be one of a disjunction
+// over the slot's implementations, each having the discriminator value of the
+// implementation and a composed object described by the implementation's
+// schema. Objects whose discriminator value selects no implementation are
+// therefore invalid.
+//
+// The composed schema is built in the [cue.Context] of k, so implementations
+// may be bound in any [thema.Runtime].
+func Compose(k ResourceKind, slots ...Slot) (*ComposedSchema, error) {
+	sch, err := k.Lineage().Schema(k.CurrentVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	var overlays []ast.Expr
+	for _, slot := range slots {
+		x, err := slotExpr(sch, slot)
+		if err != nil {
+			return nil, fmt.Errorf("slot %s of kind %s: %w", slot.path(), k.Name(), err)
+		}
+		overlays = append(overlays, x)
+	}
+
+	defer lockCUE(k.Lineage().Runtime().Context())()
+	v := sch.Underlying().LookupPath(cue.MakePath(cue.Str("schema")))
+	for _, x := range overlays {
+		v = v.Unify(v.Context().BuildExpr(x))
+	}
+	if err = v.Err(); err != nil {
+		return nil, fmt.Errorf("failed composing schema %s of kind %s: %w", sch.Version(), k.Name(), err)
+	}
+	return &ComposedSchema{
+		kind:  k,
+		sch:   sch,
+		v:     v,
+		slots: slots,
+	}, nil
+}
+
+// Underlying returns the composed schema, as the schema field of a
+// [thema.Schema].
+func (s *ComposedSchema) Underlying() cue.Value {
+	return s.v
+}
+
+// Version returns the version of the kind's schema that was composed.
+func (s *ComposedSchema) Version() thema.SyntacticVersion {
+	return s.sch.Version()
+}
+
+// Validate checks that the object in b is an instance of the composed schema,
+// as [ResourceKind.Validate] does of the kind's schemas. If the object is
+// invalid, the returned error is a [*ValidationError] describing each invalid
+// field.
+func (s *ComposedSchema) Validate(b []byte, codec Decoder) error {
+	gb, err := codec.Decode(b)
+	if err != nil {
+		return err
+	}
+	gjb, err := json.Marshal(gis{
+		Spec:     gb.Spec,
+		Metadata: gb.Metadata,
+	})
+	if err != nil {
+		return err
+	}
+
+	defer lockCUE(s.v.Context())()
+	data, err := vmux.NewJSONCodec(s.kind.MachineName()+".json").Decode(s.v.Context(), gjb)
+	if err != nil {
+		return err
+	}
+	if err = s.v.Unify(data).Validate(cue.Concrete(true), cue.All()); err != nil {
+		verr := newValidationError(err, s.kind.Name(), "", s.sch.Version(), len(s.v.Path().Selectors()))
+		// Each disjunct not selected by a valid discriminator conflicts with
+		// it, which is noise beside the errors of the selected disjunct.
+		fields := verr.Fields[:0]
+		for _, f := range verr.Fields {
+			if !s.selects(f.Path, data) {
+				fields = append(fields, f)
+			}
+		}
+		verr.Fields = fields
+		return verr
+	}
+	return nil
+}
+
+// selects indicates whether the field at path p of the data is the
+// discriminator of a slot, with a value selecting one of its implementations.
+func (s *ComposedSchema) selects(p string, data cue.Value) bool {
+	pattern := listIndex.ReplaceAllString(p, "[]")
+	for _, slot := range s.slots {
+		if pattern != strings.TrimPrefix(slot.Path+"."+slot.Discriminator, ".") {
+			continue
+		}
+		id, err := data.LookupPath(cue.ParsePath(p)).String()
+		if _, has := slot.Implementations[id]; err == nil && has {
+			return true
+		}
+	}
+	return false
+}
+
+// listIndex matches the list indices in a field path.
+var listIndex = regexp.MustCompile(`\[\d+\]`)
+
+// slotExpr returns a CUE expression that, unified with the schema field of
+// the kind's schema sch, constrains the objects at the slot to the
+// disjunction over its implementations.
+func slotExpr(sch thema.Schema, slot Slot) (ast.Expr, error) {
+	if slot.Discriminator == "" {
+		return nil, fmt.Errorf("no discriminator")
+	}
+	if len(slot.Implementations) == 0 {
+		return nil, fmt.Errorf("no implementations")
+	}
+	si, err := FindSchemaInterface(slot.Interface)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case si.IsGroup() && slot.Member == "":
+		return nil, fmt.Errorf("schema interface %s is a group, so a member must be named", si.Name())
+	case !si.IsGroup() && slot.Member != "":
+		return nil, fmt.Errorf("schema interface %s is not a group, so has no member %s", si.Name(), slot.Member)
+	}
+
+	segs := strings.Split(slot.Path, ".")
+	if slot.Path == "" {
+		segs = nil
+	}
+	if err = checkSlotPath(sch, segs, slot); err != nil {
+		return nil, err
+	}
+
+	// order the disjunction deterministically
+	ids := make([]string, 0, len(slot.Implementations))
+	for id := range slot.Implementations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var disjuncts []ast.Expr
+	for _, id := range ids {
+		impl := slot.Implementations[id]
+		if impl.Def().Properties.SchemaInterface != si.Name() {
+			return nil, fmt.Errorf("implementation %s of kind %s implements the %s schema interface, not %s", id, impl.Name(), impl.Def().Properties.SchemaInterface, si.Name())
+		}
+		obj, err := implExpr(impl, slot.Member)
+		if err != nil {
+			return nil, fmt.Errorf("implementation %s: %w", id, err)
+		}
+
+		disc := nestExpr(strings.Split(slot.Discriminator, "."), ast.NewString(id))
+		if slot.Field != "" {
+			obj = ast.NewStruct(slot.Field, obj)
+		}
+		disjuncts = append(disjuncts, ast.NewBinExpr(token.AND, disc, obj))
+	}
+	return nestExpr(segs, ast.NewBinExpr(token.OR, disjuncts...)), nil
+}
+
+// checkSlotPath checks that the slot's objects, made of the path segments
+// segs, and the slot's field within them exist in the schema field of the
+// kind's schema sch, and that the discriminator, if it exists there, is a
+// string.
+func checkSlotPath(sch thema.Schema, segs []string, slot Slot) error {
+	defer lockCUE(sch.Lineage().Runtime().Context())()
+	v := sch.Underlying().LookupPath(cue.MakePath(cue.Str("schema")))
+	for _, seg := range segs {
+		name := strings.TrimSuffix(seg, "[]")
+		if v = lookupField(v, name); !v.Exists() {
+			return fmt.Errorf("no field %s in schema %s", name, sch.Version())
+		}
+		if name != seg {
+			if v = v.LookupPath(cue.MakePath(cue.AnyIndex)); !v.Exists() {
+				return fmt.Errorf("field %s is not a list in schema %s", name, sch.Version())
+			}
+		}
+	}
+
+	if slot.Field != "" && !lookupField(v, slot.Field).Exists() {
+		return fmt.Errorf("no field %s in schema %s", slot.Field, sch.Version())
+	}
+	// the discriminator may instead be defined by the implementations, such
+	// as in an open struct
+	d := v
+	for _, name := range strings.Split(slot.Discriminator, ".") {
+		if d = lookupField(d, name); !d.Exists() {
+			return nil
+		}
+	}
+	if d.IncompleteKind()&cue.StringKind == 0 {
+		return fmt.Errorf("discriminator field %s is not a string in schema %s", slot.Discriminator, sch.Version())
+	}
+	return nil
+}
+
+// lookupField returns the field of v with the name, which may be optional.
+func lookupField(v cue.Value, name string) cue.Value {
+	if f := v.LookupPath(cue.MakePath(cue.Str(name))); f.Exists() {
+		return f
+	}
+	return v.LookupPath(cue.MakePath(cue.Str(name).Optional()))
+}
+
+// implExpr returns a self-contained CUE expression of the member of the
+// composable kind's current schema, which may be built in any [cue.Context].
+func implExpr(k Composable, member string) (ast.Expr, error) {
+	if err := checkMember(k, member); err != nil {
+		return nil, err
+	}
+	sch, err := k.Lineage().Schema(k.CurrentVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	defer lockCUE(k.Lineage().Runtime().Context())()
+	mv := memberValue(sch, member)
+	if !mv.Exists() {
+		return nil, fmt.Errorf("schema %s of kind %s has no member %s", sch.Version(), k.Name(), member)
+	}
+	// references to definitions elsewhere in the schema are inlined, so the
+	// expression may be built apart from the lineage
+	x, is := mv.Syntax(cue.Definitions(true), cue.Optional(true), cue.Docs(true)).(ast.Expr)
+	if !is {
+		return nil, fmt.Errorf("schema %s of kind %s has no expression for member %s", sch.Version(), k.Name(), member)
+	}
+	return x, nil
+}
+
+// nestExpr returns x nested within structs, and the elements of lists, along
+// the path segments segs, as parsed by slotExpr.
+func nestExpr(segs []string, x ast.Expr) ast.Expr {
+	for i := len(segs) - 1; i >= 0; i-- {
+		name := strings.TrimSuffix(segs[i], "[]")
+		if name != segs[i] {
+			x = ast.NewList(&ast.Ellipsis{Type: x})
+		}
+		x = ast.NewStruct(name, x)
+	}
+	return x
+}
+
+// path returns the path of the slot's composed objects, for use in messages.
+func (slot Slot) path() string {
+	if slot.Field == "" {
+		return slot.Path
+	}
+	if slot.Path == "" {
+		return slot.Field
+	}
+	return slot.Path + "." + slot.Field
+}
@@ -0,0 +1,262 @@
+package kindsys
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/grafana/kindsys/encoding"
+	"github.com/grafana/thema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompose(t *testing.T) {
+	var dashkind = `
+name: "TestDashboard"
+description: "Dashboards, with composed panel options and queries."
+maturity: "experimental"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		spec: {
+			title: string
+			panels?: [...#Panel]
+		}
+		#Panel: {
+			type: string
+			options?: {...}
+			targets?: [...{...}]
+		}
+	}
+}]
+`
+	var textkind = `
+name: "TextPanelCfg"
+maturity: "experimental"
+schemaInterface: "PanelCfg"
+lineage: name: "textpanelcfg"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		Options: {
+			mode: "markdown" | "html" | *"markdown"
+			content: string
+		}
+	}
+}]
+`
+	var timeserieskind = `
+name: "TimeseriesPanelCfg"
+maturity: "experimental"
+schemaInterface: "PanelCfg"
+lineage: name: "timeseriespanelcfg"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		Options: {
+			legend: #Legend
+		}
+		#Legend: {
+			showLegend: bool
+			placement?: "bottom" | "right"
+		}
+	}
+}]
+`
+	var querykind = `
+name: "PromDataQuery"
+maturity: "experimental"
+schemaInterface: "DataQuery"
+lineage: name: "promdataquery"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		refId: string
+		expr: string
+	}
+}]
+`
+
+	rt := thema.NewRuntime(ctx)
+	cdef, err := ToDef[CoreProperties](ctx.CompileString(dashkind))
+	require.NoError(t, err)
+	dash, err := BindCore(rt, cdef)
+	require.NoError(t, err)
+
+	bind := func(src string) Composable {
+		// bind each composable in its own runtime, as LoadKinds does
+		ctx := cuecontext.New()
+		def, err := ToDef[ComposableProperties](ctx.CompileString(src))
+		require.NoError(t, err)
+		k, err := BindComposable(thema.NewRuntime(ctx), def)
+		require.NoError(t, err)
+		return k
+	}
+	text, timeseries, prom := bind(textkind), bind(timeserieskind), bind(querykind)
+
+	panels := Slot{
+		Path:          "spec.panels[]",
+		Field:         "options",
+		Discriminator: "type",
+		Interface:     "PanelCfg",
+		Member:        "Options",
+		Implementations: map[string]Composable{
+			"text":       text,
+			"timeseries": timeseries,
+		},
+	}
+	queries := Slot{
+		Path:          "spec.panels[].targets[]",
+		Discriminator: "datasource.type",
+		Interface:     "DataQuery",
+		Implementations: map[string]Composable{
+			"prometheus": prom,
+		},
+	}
+	comp, err := Compose(dash, panels, queries)
+	require.NoError(t, err)
+	require.Equal(t, thema.SV(0, 0), comp.Version())
+
+	validate := func(spec string) error {
+		return comp.Validate([]byte(`{
+	"apiVersion": "testdashboard.core.grafana.com/v0-0",
+	"kind": "TestDashboard",
+	"metadata": {"name": "test", "namespace": "default"},
+	"spec": `+spec+`
+}`), &encoding.KubernetesJSONDecoder{})
+	}
+
+	require.NoError(t, validate(`{"title": "empty"}`))
+	require.NoError(t, validate(`{"title": "panels", "panels": [
+		{"type": "text", "options": {"content": "# Hi"}},
+		{"type": "timeseries", "options": {"legend": {"showLegend": true}}, "targets": [
+			{"refId": "A", "expr": "up", "datasource": {"type": "prometheus"}}
+		]}
+	]}`))
+
+	err = validate(`{"title": "bad", "panels": [{"type": "text", "options": {"mode": "text", "content": "# Hi"}}]}`)
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, "TestDashboard", verr.Kind)
+	require.Equal(t, []FieldError{{
+		Path:    "spec.panels[0].options.mode",
+		Message: `conflicting values "html" and "text"; conflicting values "markdown" and "text"`,
+	}}, verr.Fields)
+
+	err = validate(`{"title": "bad", "panels": [
+		{"type": "text", "options": {"content": "# Hi"}},
+		{"type": "timeseries", "options": {"legend": {"placement": "top", "showLegend": true}}}
+	]}`)
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 1)
+	require.Equal(t, "spec.panels[1].options.legend.placement", verr.Fields[0].Path)
+
+	err = validate(`{"title": "bad", "panels": [{"type": "text", "options": {"content": ""}, "targets": [
+		{"refId": "A", "datasource": {"type": "prometheus"}}
+	]}]}`)
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, []FieldError{{Path: "spec.panels[0].targets[0].expr", Message: "missing required field of type string"}}, verr.Fields)
+
+	// no implementation for the panel's type
+	err = validate(`{"title": "bad", "panels": [{"type": "graph", "options": {}}]}`)
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 1)
+	require.Equal(t, "spec.panels[0].type", verr.Fields[0].Path)
+
+	// the kind's own validation is unaffected
+	require.NoError(t, dash.Validate([]byte(`{
+	"apiVersion": "testdashboard.core.grafana.com/v0-0",
+	"kind": "TestDashboard",
+	"metadata": {"name": "test", "namespace": "default"},
+	"spec": {"title": "graph", "panels": [{"type": "graph"}]}
+}`), &encoding.KubernetesJSONDecoder{}))
+}
+
+func TestComposeErrors(t *testing.T) {
+	var dashkind = `
+name: "TestDashboard"
+description: "Dashboards, with composed panel options."
+maturity: "experimental"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: spec: panels: [...{type: string, options?: {...}}]
+}]
+`
+	var querykind = `
+name: "TestDataQuery"
+maturity: "experimental"
+schemaInterface: "DataQuery"
+lineage: name: "testdataquery"
+lineage: schemas: [{version: [0, 0], schema: refId: string}]
+`
+
+	rt := thema.NewRuntime(ctx)
+	cdef, err := ToDef[CoreProperties](ctx.CompileString(dashkind))
+	require.NoError(t, err)
+	dash, err := BindCore(rt, cdef)
+	require.NoError(t, err)
+	qdef, err := ToDef[ComposableProperties](ctx.CompileString(querykind))
+	require.NoError(t, err)
+	query, err := BindComposable(rt, qdef)
+	require.NoError(t, err)
+
+	valid := Slot{
+		Path:            "spec.panels[]",
+		Field:           "options",
+		Discriminator:   "type",
+		Interface:       "DataQuery",
+		Implementations: map[string]Composable{"test": query},
+	}
+	_, err = Compose(dash, valid)
+	require.NoError(t, err)
+
+	tt := map[string]struct {
+		edit func(s *Slot)
+		err  string
+	}{
+		"no discriminator": {
+			edit: func(s *Slot) { s.Discriminator = "" },
+			err:  "no discriminator",
+		},
+		"no implementations": {
+			edit: func(s *Slot) { s.Implementations = nil },
+			err:  "no implementations",
+		},
+		"unknown interface": {
+			edit: func(s *Slot) { s.Interface = "Nope" },
+			err:  "unsupported slot: Nope",
+		},
+		"group member": {
+			edit: func(s *Slot) { s.Member = "Options" },
+			err:  "not a group",
+		},
+		"wrong interface": {
+			edit: func(s *Slot) { s.Interface, s.Member = "PanelCfg", "Options" },
+			err:  "implements the DataQuery schema interface, not PanelCfg",
+		},
+		"missing path": {
+			edit: func(s *Slot) { s.Path = "spec.rows[]" },
+			err:  "no field rows",
+		},
+		"not a list": {
+			edit: func(s *Slot) { s.Path = "spec[]" },
+			err:  "field spec is not a list",
+		},
+		"missing field": {
+			edit: func(s *Slot) { s.Field = "fieldConfig" },
+			err:  "no field fieldConfig",
+		},
+		"discriminator not a string": {
+			edit: func(s *Slot) { s.Field, s.Discriminator = "", "options" },
+			err:  "discriminator field options is not a string",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			slot := valid
+			tc.edit(&slot)
+			_, err := Compose(dash, slot)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package kindsys
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/kindsys/encoding"
+	"github.com/grafana/thema"
+)
+
+func TestConcurrentValidate(t *testing.T) {
+	var testkind = `
+name: "ConcurrentKind"
+description: "For stress testing."
+maturity: "experimental"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		spec: {
+			title: string
+			count: int32
+		}
+	}
+}]
+`
+	rt := thema.NewRuntime(ctx)
+	def, err := ToDef[CoreProperties](ctx.CompileString(testkind))
+	require.NoError(t, err)
+	k, err := BindCore(rt, def)
+	require.NoError(t, err)
+	// a kind value in the same context, converted while k is in use
+	kv := ctx.CompileString(testkind)
+
+	resource := func(i int) []byte {
+		count := fmt.Sprint(i)
+		if i%3 == 0 {
+			count = `"not a number"`
+		}
+		return []byte(fmt.Sprintf(`{
+	"apiVersion": "concurrentkind.core.grafana.com/v0-0",
+	"kind": "ConcurrentKind",
+	"metadata": {"name": "r%d", "namespace": "default"},
+	"spec": {"title": "resource %d", "count": %s}
+}`, i, i, count))
+	}
+
+	n := 2000
+	if testing.Short() {
+		n = 200
+	}
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	deferrs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%10 == 1 {
+				_, deferrs[i] = ToSomeDef(kv)
+			}
+			if i%2 == 0 {
+				errs[i] = k.Validate(resource(i), &encoding.KubernetesJSONDecoder{})
+				return
+			}
+			_, errs[i] = k.FromBytes(resource(i), &encoding.KubernetesJSONDecoder{})
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range deferrs {
+		require.NoError(t, err, "kind %d", i)
+	}
+
+	for i, err := range errs {
+		if i%3 == 0 {
+			require.Error(t, err, "resource %d", i)
+		} else {
+			require.NoError(t, err, "resource %d", i)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package kindsys
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"cuelang.org/go/cue"
+	"github.com/grafana/thema"
+)
+
+// APIVersion returns the name of the Kubernetes API version that serves the
+// kind schema with the provided version, such that 0.1 becomes "v0-1".
+func APIVersion(v thema.SyntacticVersion) string {
+	return fmt.Sprintf("v%d-%d", v[0], v[1])
+}
+
+var apiVersionRE = regexp.MustCompile(`^v(\d+)-(\d+)$`)
+
+// ParseAPIVersion parses the name of a Kubernetes API version, as returned
+// from [APIVersion], into the version of the kind schema it serves.
+func ParseAPIVersion(s string) (thema.SyntacticVersion, error) {
+	m := apiVersionRE.FindStringSubmatch(s)
+	if m == nil {
+		return thema.SyntacticVersion{}, fmt.Errorf("invalid API version %q, expected the form v<major>-<minor>", s)
+	}
+	var v thema.SyntacticVersion
+	for i := range v {
+		n, err := strconv.ParseUint(m[i+1], 10, 32)
+		if err != nil {
+			return thema.SyntacticVersion{}, fmt.Errorf("invalid API version %q: %w", s, err)
+		}
+		v[i] = uint(n)
+	}
+	return v, nil
+}
+
+// ConvertConfig holds options for [Convert].
+type ConvertConfig struct {
+	// Decoder decodes the input object, determining its shape and format.
+	Decoder Decoder
+
+	// Encoder encodes the output object, determining its shape and format.
+	Encoder Encoder
+
+	// Version, if non-nil, is the version of the kind's schema to which the
+	// object is translated. If nil, the object keeps the version of the schema
+	// it is valid against.
+	Version *thema.SyntacticVersion
+}
+
+// Convert converts a serialized resource of the provided kind between shapes,
+// formats and schema versions. The resource is decoded, validated against the
+// kind's schemas, translated to the configured version through the kind's
+// lineage, then encoded.
+//
+// Only the resource's spec is translated. Its metadata and subresources, such
+// as status, are carried through unchanged.
+//
+// The returned lacunas describe any gaps in translation, and are nil if the
+// resource was not translated.
+func Convert(k ResourceKind, b []byte, cfg ConvertConfig) ([]byte, thema.TranslationLacunas, error) {
+	if cfg.Decoder == nil || cfg.Encoder == nil {
+		return nil, nil, errors.New("a decoder and an encoder are required")
+	}
+	if cfg.Version != nil {
+		if _, err := k.Lineage().Schema(*cfg.Version); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	gb, err := cfg.Decoder.Decode(b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer lockCUE(k.Lineage().Runtime().Context())()
+	inst, err := grafanaShapeToInstance(k, gb)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var lac thema.TranslationLacunas
+	if cfg.Version != nil && *cfg.Version != inst.Schema().Version() {
+		from := inst.Schema().Version()
+		if inst, lac, err = inst.Translate(*cfg.Version); err != nil {
+			return nil, nil, fmt.Errorf("failed translating from %s to %s: %w", from, *cfg.Version, err)
+		}
+		if gb.Spec, err = json.Marshal(inst.Underlying().LookupPath(cue.ParsePath("spec"))); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	gb.Kind, gb.Group = k.Name(), k.Group()
+	gb.Version = APIVersion(inst.Schema().Version())
+	out, err := cfg.Encoder.Encode(gb)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, lac, nil
+}
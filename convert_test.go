@@ -0,0 +1,122 @@
+package kindsys
+
+import (
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/grafana/kindsys/encoding"
+	"github.com/grafana/thema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIVersion(t *testing.T) {
+	require.Equal(t, "v1-12", APIVersion(thema.SV(1, 12)))
+
+	v, err := ParseAPIVersion("v1-12")
+	require.NoError(t, err)
+	require.Equal(t, thema.SV(1, 12), v)
+
+	for _, s := range []string{"", "v1", "1-12", "v1-12beta1", "v-1-2"} {
+		_, err = ParseAPIVersion(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	var testkind = `package kind
+
+import "github.com/grafana/kindsys"
+
+kindsys.Core
+name: "TestKind"
+description: "Blammo!"
+maturity: "experimental"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		spec: title: string
+	}
+}, {
+	version: [0, 1]
+	schema: {
+		spec: {
+			title: string
+			color?: string | *"blue"
+		}
+	}
+}]
+`
+
+	var testresource = `
+{
+	"apiVersion": "testkind.core.grafana.com/v0-0",
+	"kind": "TestKind",
+	"metadata": {
+		"name": "test",
+		"namespace": "default",
+		"annotations": {
+			"grafana.com/createdBy": "me",
+			"grafana.com/updatedBy": "you",
+			"grafana.com/updateTimestamp": "2023-07-06T03:08:01Z",
+			"grafana.com/team": "a-team"
+		}
+	},
+	"spec": {
+		"title": "Hello"
+	},
+	"status": {
+		"additionalFields": {"phase": "ready"}
+	}
+}`
+
+	ctx := cuecontext.New()
+	def, err := LoadCoreKindDef(testKindFS(testkind), ".", ctx)
+	require.NoError(t, err)
+	k, err := BindCore(thema.NewRuntime(ctx), def)
+	require.NoError(t, err)
+
+	v01 := thema.SV(0, 1)
+	b, lac, err := Convert(k, []byte(testresource), ConvertConfig{
+		Decoder: &encoding.KubernetesJSONDecoder{},
+		Encoder: &encoding.GrafanaYAMLEncoder{},
+		Version: &v01,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, lac)
+	require.Contains(t, string(b), "apiVersion: v0-1\n")
+	// metadata, custom metadata and subresources are carried through
+	require.Contains(t, string(b), "name: test\n")
+	require.Contains(t, string(b), "team: a-team\n")
+	require.Contains(t, string(b), "phase: ready\n")
+
+	// and back again, without translation
+	b, lac, err = Convert(k, []byte(strings.Replace(string(b), "title: Hello", "title: Hello\n  color: red", 1)), ConvertConfig{
+		Decoder: &encoding.GrafanaYAMLDecoder{},
+		Encoder: &encoding.KubernetesJSONEncoder{},
+	})
+	require.NoError(t, err)
+	require.Nil(t, lac)
+	res, err := (&encoding.KubernetesJSONDecoder{}).Decode(b)
+	require.NoError(t, err)
+	require.Equal(t, "v0-1", res.Version)
+	require.Equal(t, "testkind.core.grafana.com", res.Group)
+	require.JSONEq(t, `{"title":"Hello","color":"red"}`, string(res.Spec))
+	require.JSONEq(t, `{"team":"a-team"}`, string(res.CustomMetadata))
+	require.Contains(t, string(res.Metadata), `"name":"test"`)
+	require.Contains(t, string(res.Metadata), `"namespace":"default"`)
+
+	v20 := thema.SV(2, 0)
+	_, _, err = Convert(k, []byte(testresource), ConvertConfig{
+		Decoder: &encoding.KubernetesJSONDecoder{},
+		Encoder: &encoding.KubernetesJSONEncoder{},
+		Version: &v20,
+	})
+	require.Error(t, err)
+
+	_, _, err = Convert(k, []byte(`{"apiVersion": "testkind.core.grafana.com/v0-0", "kind": "TestKind", "spec": {"title": 42}}`), ConvertConfig{
+		Decoder: &encoding.KubernetesJSONDecoder{},
+		Encoder: &encoding.KubernetesJSONEncoder{},
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,35 @@
+package kindsys
+
+import (
+	"sync"
+
+	"cuelang.org/go/cue"
+)
+
+// ctxLocks holds one *sync.Mutex for each cue.Context on which kindsys
+// performs CUE operations that may be called concurrently.
+//
+// Entries are never removed. cue.Contexts are expected to be few and
+// long-lived, typically one per [thema.Runtime], so this is a bounded cost.
+var ctxLocks sync.Map
+
+// lockCUE acquires the lock guarding the provided cue.Context, and returns a
+// func that releases it.
+//
+// A cue.Context, and all cue.Values created from it, are not safe for
+// concurrent use: evaluation lazily mutates shared internal state, even for
+// operations that look read-only, like Unify or Validate. kindsys therefore
+// serializes its CUE operations per cue.Context. Kinds bound to the same
+// [thema.Runtime] share a cue.Context, and thus a lock; kinds bound to
+// separate runtimes, each with its own cue.Context, do not contend at all.
+//
+// The lock is not reentrant. Acquire it only in exported entry points, never
+// in helpers they call.
+func lockCUE(ctx *cue.Context) (unlock func()) {
+	if ctx == nil {
+		ctx = cueContext()
+	}
+	mu, _ := ctxLocks.LoadOrStore(ctx, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
@@ -0,0 +1,99 @@
+package kindsys
+
+import "reflect"
+
+// DeepCopyValue returns a deep copy of v, a value as unmarshaled from JSON into
+// an any: a map[string]any, []any, or a scalar. Maps and slices are copied
+// recursively, and all other values are returned as they are.
+//
+// Generated DeepCopyInto methods use DeepCopyValue to copy fields of type any.
+func DeepCopyValue(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		return deepCopyMap(x)
+	case []any:
+		if x == nil {
+			return x
+		}
+		cp := make([]any, len(x))
+		for i := range x {
+			cp[i] = DeepCopyValue(x[i])
+		}
+		return cp
+	default:
+		return v
+	}
+}
+
+func deepCopyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	cp := make(map[string]any, len(m))
+	for k, v := range m {
+		cp[k] = DeepCopyValue(v)
+	}
+	return cp
+}
+
+// DeepCopyInto copies the receiver into out, sharing no maps, slices or
+// pointers with it.
+func (in *CommonMetadata) DeepCopyInto(out *CommonMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		out.Labels = make(map[string]string, len(in.Labels))
+		for k, v := range in.Labels {
+			out.Labels[k] = v
+		}
+	}
+	if in.DeletionTimestamp != nil {
+		t := *in.DeletionTimestamp
+		out.DeletionTimestamp = &t
+	}
+	if in.Finalizers != nil {
+		out.Finalizers = make([]string, len(in.Finalizers))
+		copy(out.Finalizers, in.Finalizers)
+	}
+	out.ExtraFields = deepCopyMap(in.ExtraFields)
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *CommonMetadata) DeepCopy() *CommonMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (s SimpleCustomMetadata) DeepCopy() SimpleCustomMetadata {
+	return deepCopyMap(s)
+}
+
+// DeepCopyInto copies the receiver into out, sharing no maps, slices or
+// pointers with it.
+func (b *BasicMetadataObject) DeepCopyInto(out *BasicMetadataObject) {
+	out.StaticMeta = b.StaticMeta
+	b.CommonMeta.DeepCopyInto(&out.CommonMeta)
+	out.CustomMeta = b.CustomMeta.DeepCopy()
+}
+
+// deepCopyResource returns a deep copy of in, if it has a DeepCopyInto method
+// taking its own type, such as those generated by GoDeepCopyJenny in
+// pkg/codegen.
+func deepCopyResource(in any) (Resource, bool) {
+	val := reflect.ValueOf(in)
+	if val.Kind() != reflect.Pointer || val.IsNil() {
+		return nil, false
+	}
+	m := val.MethodByName("DeepCopyInto")
+	if !m.IsValid() || m.Type().NumIn() != 1 || m.Type().NumOut() != 0 || m.Type().In(0) != val.Type() {
+		return nil, false
+	}
+	cpy := reflect.New(val.Type().Elem())
+	m.Call([]reflect.Value{cpy})
+	r, ok := cpy.Interface().(Resource)
+	return r, ok
+}
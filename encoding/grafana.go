@@ -0,0 +1,116 @@
+package encoding
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Keys of the top-level fields of a grafana-shaped object. All other
+// top-level fields are subresources, such as status.
+const (
+	grafanaKindKey           = "kind"
+	grafanaGroupKey          = "group"
+	grafanaVersionKey        = "apiVersion"
+	grafanaMetadataKey       = "metadata"
+	grafanaCustomMetadataKey = "customMetadata"
+	grafanaSpecKey           = "spec"
+)
+
+// GrafanaJSONEncoder is a grafana encoder for JSON wire format
+type GrafanaJSONEncoder struct{}
+
+// Encode accepts GrafanaShapedBytes which are in a JSON wire format,
+// and produces a JSON-encoded grafana-shaped payload
+func (g *GrafanaJSONEncoder) Encode(bytes GrafanaShapeBytes) ([]byte, error) {
+	obj := make(map[string]json.RawMessage)
+	for key, val := range bytes.Subresources {
+		obj[key] = val
+	}
+
+	var err error
+	obj[grafanaKindKey], err = json.Marshal(bytes.Kind)
+	if err != nil {
+		return nil, err
+	}
+	obj[grafanaGroupKey], err = json.Marshal(bytes.Group)
+	if err != nil {
+		return nil, err
+	}
+	obj[grafanaVersionKey], err = json.Marshal(bytes.Version)
+	if err != nil {
+		return nil, err
+	}
+	obj[grafanaMetadataKey] = bytes.Metadata
+	if len(bytes.CustomMetadata) > 0 {
+		obj[grafanaCustomMetadataKey] = bytes.CustomMetadata
+	}
+	obj[grafanaSpecKey] = bytes.Spec
+	return json.Marshal(obj)
+}
+
+// GrafanaJSONDecoder is a grafana decoder for JSON wire format
+type GrafanaJSONDecoder struct{}
+
+// Decode accepts JSON-encoded bytes of a grafana-shaped object,
+// and returns JSON-encoded GrafanaShapeBytes of that object
+func (g *GrafanaJSONDecoder) Decode(bytes []byte) (GrafanaShapeBytes, error) {
+	partial := make(map[string]json.RawMessage)
+	err := json.Unmarshal(bytes, &partial)
+	if err != nil {
+		return GrafanaShapeBytes{}, err
+	}
+	res := GrafanaShapeBytes{
+		Subresources: make(map[string][]byte),
+	}
+	for key, val := range partial {
+		switch key {
+		case grafanaKindKey:
+			err = json.Unmarshal(val, &res.Kind)
+		case grafanaGroupKey:
+			err = json.Unmarshal(val, &res.Group)
+		case grafanaVersionKey:
+			err = json.Unmarshal(val, &res.Version)
+		case grafanaMetadataKey:
+			res.Metadata = val
+		case grafanaCustomMetadataKey:
+			res.CustomMetadata = val
+		case grafanaSpecKey:
+			res.Spec = val
+		default:
+			res.Subresources[key] = val
+		}
+		if err != nil {
+			return res, fmt.Errorf("unable to decode %s: %w", key, err)
+		}
+	}
+	if res.Metadata == nil {
+		res.Metadata = []byte("{}")
+	}
+	return res, nil
+}
+
+// GrafanaYAMLEncoder is a grafana encoder for YAML wire format
+type GrafanaYAMLEncoder struct{}
+
+// Encode accepts GrafanaShapedBytes which are in a JSON wire format,
+// and produces a YAML-encoded grafana-shaped payload
+func (g *GrafanaYAMLEncoder) Encode(bytes GrafanaShapeBytes) ([]byte, error) {
+	j, err := (&GrafanaJSONEncoder{}).Encode(bytes)
+	if err != nil {
+		return nil, err
+	}
+	return jsonToYAML(j)
+}
+
+// GrafanaYAMLDecoder is a grafana decoder for YAML wire format
+type GrafanaYAMLDecoder struct{}
+
+// Decode accepts YAML-encoded bytes of a grafana-shaped object,
+// and returns JSON-encoded GrafanaShapeBytes of that object
+func (g *GrafanaYAMLDecoder) Decode(bytes []byte) (GrafanaShapeBytes, error) {
+	j, err := yamlToJSON(bytes)
+	if err != nil {
+		return GrafanaShapeBytes{}, err
+	}
+	return (&GrafanaJSONDecoder{}).Decode(j)
+}
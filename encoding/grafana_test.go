@@ -0,0 +1,52 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrafanaJSONRoundTrip(t *testing.T) {
+	gb := GrafanaShapeBytes{
+		Kind:           testKind,
+		Group:          testGroup,
+		Version:        testVersion,
+		Spec:           testGrafanaSpecJSONBytes,
+		Metadata:       testCommonMetadataJSONBytes,
+		CustomMetadata: testCustomMetadataJSONBytes,
+		Subresources: map[string][]byte{
+			"status": testStatusSubresourceJSONBytes,
+		},
+	}
+
+	enc, dec := GrafanaJSONEncoder{}, GrafanaJSONDecoder{}
+	b, err := enc.Encode(gb)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"apiVersion":"`+testVersion+`"`)
+	assert.Contains(t, string(b), `"status":{"state":"active"}`)
+
+	res, err := dec.Decode(b)
+	require.NoError(t, err)
+	assert.Equal(t, gb, res)
+
+	yenc, ydec := GrafanaYAMLEncoder{}, GrafanaYAMLDecoder{}
+	yb, err := yenc.Encode(gb)
+	require.NoError(t, err)
+	assert.Contains(t, string(yb), "kind: "+testKind+"\n")
+	res, err = ydec.Decode(yb)
+	require.NoError(t, err)
+	assert.Equal(t, gb.Spec, res.Spec)
+	assert.Equal(t, gb.Subresources, res.Subresources)
+}
+
+func TestGrafanaJSONDecoder_Decode(t *testing.T) {
+	dec := GrafanaJSONDecoder{}
+	res, err := dec.Decode([]byte(`{"kind": "Test", "spec": {}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "Test", res.Kind)
+	assert.Equal(t, []byte("{}"), res.Metadata)
+
+	_, err = dec.Decode([]byte(`{"kind": 42}`))
+	assert.ErrorContains(t, err, "unable to decode kind")
+}
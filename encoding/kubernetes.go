@@ -213,6 +213,18 @@ func (k *KubernetesJSONDecoder) Decode(bytes []byte) (GrafanaShapeBytes, error)
 		Labels:            kubeMeta.Labels,
 		CreationTimestamp: kubeMeta.CreationTimestamp.Time.UTC(),
 		Finalizers:        kubeMeta.Finalizers,
+		ExtraFields: map[string]any{
+			"generation":  kubeMeta.Generation,
+			"annotations": kubeMeta.Annotations,
+		},
+	}
+	// name and namespace have no place in CommonMetadata, but are needed to
+	// encode back to a kubernetes object
+	if kubeMeta.Name != "" {
+		cmd.ExtraFields["name"] = kubeMeta.Name
+	}
+	if kubeMeta.Namespace != "" {
+		cmd.ExtraFields["namespace"] = kubeMeta.Namespace
 	}
 	if kubeMeta.OwnerReferences != nil && len(kubeMeta.OwnerReferences) > 0 {
 		cmd.ExtraFields["ownerReferences"] = kubeMeta.OwnerReferences
@@ -249,12 +261,6 @@ func (k *KubernetesJSONDecoder) Decode(bytes []byte) (GrafanaShapeBytes, error)
 		//delete(kubeMeta.Annotations, key)
 		customMeta[tkey] = val
 	}
-	// With annotations keys trimmed out of the original, we can add it to extra fields in common metadata
-	cmd.ExtraFields = map[string]any{
-		"generation":  kubeMeta.Generation,
-		"annotations": kubeMeta.Annotations,
-	}
-
 	res.Metadata, err = json.Marshal(cmd)
 	if err != nil {
 		return res, err
@@ -0,0 +1,60 @@
+package encoding
+
+import (
+	"cuelang.org/go/cue/cuecontext"
+	cuejson "cuelang.org/go/encoding/json"
+	"cuelang.org/go/encoding/yaml"
+)
+
+// KubernetesYAMLEncoder is a kubernetes encoder for YAML wire format
+type KubernetesYAMLEncoder struct{}
+
+// Encode accepts GrafanaShapedBytes which are in a JSON wire format,
+// and produces a YAML-encoded kubernetes payload
+func (k *KubernetesYAMLEncoder) Encode(bytes GrafanaShapeBytes) ([]byte, error) {
+	j, err := (&KubernetesJSONEncoder{}).Encode(bytes)
+	if err != nil {
+		return nil, err
+	}
+	return jsonToYAML(j)
+}
+
+// KubernetesYAMLDecoder is a kubernetes decoder for YAML wire format
+type KubernetesYAMLDecoder struct{}
+
+// Decode accepts YAML-encoded bytes of a kubernetes object,
+// and returns JSON-encoded GrafanaShapeBytes of that object
+func (k *KubernetesYAMLDecoder) Decode(bytes []byte) (GrafanaShapeBytes, error) {
+	j, err := yamlToJSON(bytes)
+	if err != nil {
+		return GrafanaShapeBytes{}, err
+	}
+	return (&KubernetesJSONDecoder{}).Decode(j)
+}
+
+// yamlToJSON converts a single YAML document to JSON, preserving the order of
+// object keys.
+func yamlToJSON(b []byte) ([]byte, error) {
+	f, err := yaml.Extract("", b)
+	if err != nil {
+		return nil, err
+	}
+	v := cuecontext.New().BuildFile(f)
+	if v.Err() != nil {
+		return nil, v.Err()
+	}
+	return v.MarshalJSON()
+}
+
+// jsonToYAML converts JSON to YAML, preserving the order of object keys.
+func jsonToYAML(b []byte) ([]byte, error) {
+	expr, err := cuejson.Extract("", b)
+	if err != nil {
+		return nil, err
+	}
+	v := cuecontext.New().BuildExpr(expr)
+	if v.Err() != nil {
+		return nil, v.Err()
+	}
+	return yaml.Encode(v)
+}
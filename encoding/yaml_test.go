@@ -0,0 +1,36 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKubernetesYAMLRoundTrip(t *testing.T) {
+	jdec, ydec := KubernetesJSONDecoder{}, KubernetesYAMLDecoder{}
+	expected, err := jdec.Decode(testKubernetesBytes)
+	require.NoError(t, err)
+
+	yb, err := jsonToYAML(testKubernetesBytes)
+	require.NoError(t, err)
+	assert.Contains(t, string(yb), "apiVersion: "+testGroup+"/"+testVersion)
+
+	res, err := ydec.Decode(yb)
+	require.NoError(t, err)
+	assert.Equal(t, expected, res)
+
+	enc := KubernetesYAMLEncoder{}
+	out, err := enc.Encode(res)
+	require.NoError(t, err)
+	res, err = ydec.Decode(out)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Spec, res.Spec)
+	assert.Equal(t, expected.Subresources, res.Subresources)
+}
+
+func TestKubernetesYAMLDecoder_Invalid(t *testing.T) {
+	dec := KubernetesYAMLDecoder{}
+	_, err := dec.Decode([]byte("kind: [unterminated"))
+	assert.Error(t, err)
+}
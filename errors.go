@@ -15,4 +15,8 @@ var (
 
 	// ErrInvalidCUE indicates that the CUE representing the kind is invalid.
 	ErrInvalidCUE = errors.New("CUE syntax error")
+
+	// ErrUnknownFrameworkVersion indicates that a kind definition targets a
+	// version of the kindsys CUE framework that does not exist.
+	ErrUnknownFrameworkVersion = errors.New("unknown kindsys framework version")
 )
@@ -0,0 +1,198 @@
+package kindsys
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"cuelang.org/go/cue"
+)
+
+// embeddedFrameworkVersion is the version of the kindsys CUE framework at the
+// root of this repository, as embedded in [CueSchemaFS].
+const embeddedFrameworkVersion = "v0"
+
+// frameworks holds the file system for each version of the kindsys CUE
+// framework, keyed by version. Each is laid out like CueSchemaFS: a
+// cue.mod/module.cue and the framework's .cue files at the root.
+//
+// Versions other than the embedded one are snapshots of the framework as it
+// was at that version, kept so that kinds written against them can be loaded
+// with the rules they were authored under. No version has been superseded yet,
+// so only the embedded one is held here; the tests register the snapshot in
+// testdata/frameworks/v1.
+//
+// The latest version is always found with latestFramework, so that all
+// loading agrees on it.
+var frameworks = map[string]fs.FS{
+	embeddedFrameworkVersion: CueSchemaFS,
+}
+
+// fwCache memoizes the framework cue.Value for each version, as built in the
+// singleton cue.Context.
+var fwCache sync.Map
+
+// FrameworkVersions returns the versions of the kindsys CUE framework that
+// kind definitions may target, oldest first.
+//
+// Kind definitions may pin the framework version they were written against
+// with a file-level attribute preceding their package clause:
+//
+//	@kindsys(framework="v0")
+//
+//	package folder
+//
+// Definitions without such an attribute are loaded with the latest version.
+func FrameworkVersions() []string {
+	vers := make([]string, 0, len(frameworks))
+	for ver := range frameworks {
+		vers = append(vers, ver)
+	}
+	sort.Slice(vers, func(i, j int) bool {
+		return frameworkVersionLess(vers[i], vers[j])
+	})
+	return vers
+}
+
+// latestFramework returns the newest of [FrameworkVersions].
+func latestFramework() string {
+	vers := FrameworkVersions()
+	return vers[len(vers)-1]
+}
+
+// CUEFrameworkVersion is [CUEFramework], but returns the specified version of
+// the kindsys CUE framework. An error is returned if no such version exists.
+//
+// As with CUEFramework, calling this with a nil [cue.Context] memoizes the
+// result.
+func CUEFrameworkVersion(ctx *cue.Context, version string) (cue.Value, error) {
+	fwfs, err := frameworkFS(version)
+	if err != nil {
+		return cue.Value{}, err
+	}
+	if fwfs == CueSchemaFS {
+		return CUEFramework(ctx), nil
+	}
+
+	if ctx != nil && ctx != cueContext() {
+		return doLoadFrameworkCUE(ctx, fwfs)
+	}
+	if v, has := fwCache.Load(version); has {
+		return v.(cue.Value), nil
+	}
+	v, err := doLoadFrameworkCUE(cueContext(), fwfs)
+	if err != nil {
+		return v, err
+	}
+	fwCache.Store(version, v)
+	return v, nil
+}
+
+// frameworkFS returns the file system containing the specified version of
+// the kindsys CUE framework.
+func frameworkFS(version string) (fs.FS, error) {
+	fwfs, has := frameworks[version]
+	if !has {
+		return nil, fmt.Errorf("%w: %q, available versions are %s", ErrUnknownFrameworkVersion, version, strings.Join(FrameworkVersions(), ", "))
+	}
+	return fwfs, nil
+}
+
+// frameworkVersionLess reports whether framework version a precedes b.
+// Versions are of the form "v<N>"; any that are not sort after those that are.
+func frameworkVersionLess(a, b string) bool {
+	na, erra := strconv.Atoi(strings.TrimPrefix(a, "v"))
+	nb, errb := strconv.Atoi(strings.TrimPrefix(b, "v"))
+	switch {
+	case erra != nil && errb != nil:
+		return a < b
+	case erra != nil:
+		return false
+	case errb != nil:
+		return true
+	}
+	return na < nb
+}
+
+// FrameworkMigration reports whether a kind definition that targets one version
+// of the kindsys CUE framework is also valid under each newer version.
+type FrameworkMigration struct {
+	// Path is the path of the kind definition's directory.
+	Path string
+	// From is the framework version targeted by the kind definition.
+	From string
+	// Results holds the outcome of loading the kind definition under each
+	// framework version newer than From, oldest first.
+	Results []FrameworkMigrationResult
+}
+
+// FrameworkMigrationResult is the outcome of loading a kind definition under a
+// single framework version.
+type FrameworkMigrationResult struct {
+	// Version is the framework version the kind definition was loaded under.
+	Version string
+	// Err is the error encountered loading the kind definition under Version,
+	// or nil if it is valid.
+	Err error
+}
+
+// Ok indicates whether the kind definition is valid under all newer framework
+// versions, and thus may be moved to the latest without changes.
+func (m FrameworkMigration) Ok() bool {
+	for _, r := range m.Results {
+		if r.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (m FrameworkMigration) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: targets kindsys framework %s", m.Path, m.From)
+	if len(m.Results) == 0 {
+		b.WriteString(", the latest")
+	}
+	for _, r := range m.Results {
+		if r.Err == nil {
+			fmt.Fprintf(&b, "\n\t%s: ok", r.Version)
+		} else {
+			fmt.Fprintf(&b, "\n\t%s: %s", r.Version, strings.ReplaceAll(r.Err.Error(), "\n", "\n\t\t"))
+		}
+	}
+	return b.String()
+}
+
+// CheckFrameworkMigration loads the kind definition in the directory at
+// defpath within fsys, as with [LoadKindDef], then loads it again under each
+// framework version newer than the one it targets, reporting the outcome of
+// each in the returned FrameworkMigration.
+//
+// An error is returned only if the kind definition is invalid under the
+// framework version it targets.
+func CheckFrameworkMigration(fsys fs.FS, defpath string, ctx *cue.Context) (FrameworkMigration, error) {
+	m := FrameworkMigration{Path: defpath}
+	v, from, err := loadKindValue(fsys, defpath, ctx, "")
+	if err != nil {
+		return m, err
+	}
+	if _, err = toSomeDef(v, from); err != nil {
+		return m, fmt.Errorf("%s: %w", defpath, err)
+	}
+	m.From = from
+
+	for _, ver := range FrameworkVersions() {
+		if !frameworkVersionLess(from, ver) {
+			continue
+		}
+		res := FrameworkMigrationResult{Version: ver}
+		if v, _, res.Err = loadKindValue(fsys, defpath, ctx, ver); res.Err == nil {
+			_, res.Err = toSomeDef(v, ver)
+		}
+		m.Results = append(m.Results, res)
+	}
+	return m, nil
+}
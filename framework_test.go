@@ -0,0 +1,152 @@
+package kindsys
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"cuelang.org/go/cue"
+	"github.com/stretchr/testify/require"
+)
+
+// withStricterFramework registers the framework snapshot in
+// testdata/frameworks/v1 as version v1. It is identical to v0 except that it
+// only permits kinds with the "merged" maturity.
+func withStricterFramework(t *testing.T) {
+	t.Helper()
+	frameworks["v1"] = os.DirFS("testdata/frameworks/v1")
+	t.Cleanup(func() {
+		delete(frameworks, "v1")
+		fwCache.Delete("v1")
+	})
+}
+
+func testCoreKindFS(header, maturity string) fstest.MapFS {
+	return fstest.MapFS{
+		"kind/kind.cue": &fstest.MapFile{Data: []byte(header + `package kind
+
+import "github.com/grafana/kindsys"
+
+kindsys.Core
+name:        "TestCore"
+maturity:    "` + maturity + `"
+description: "A core kind for tests."
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		spec: title: string
+	}
+}]
+`)},
+	}
+}
+
+func TestFrameworkVersions(t *testing.T) {
+	require.Equal(t, []string{"v0"}, FrameworkVersions())
+	require.Equal(t, "v0", latestFramework())
+
+	withStricterFramework(t)
+	require.Equal(t, []string{"v0", "v1"}, FrameworkVersions())
+	require.Equal(t, "v1", latestFramework())
+
+	v, err := CUEFrameworkVersion(nil, "v1")
+	require.NoError(t, err)
+	require.True(t, v.LookupPath(cue.ParsePath("Core")).Exists())
+	_, cached := fwCache.Load("v1")
+	require.True(t, cached)
+
+	_, err = CUEFrameworkVersion(nil, "v9")
+	require.True(t, errors.Is(err, ErrUnknownFrameworkVersion), err)
+}
+
+func TestFrameworkPinning(t *testing.T) {
+	withStricterFramework(t)
+
+	t.Run("unpinned uses latest", func(t *testing.T) {
+		_, err := LoadCoreKindDef(testCoreKindFS("", "experimental"), "kind", nil)
+		require.Error(t, err)
+		_, err = LoadCoreKindDef(testCoreKindFS("", "merged"), "kind", nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("pinned to older version", func(t *testing.T) {
+		def, err := LoadKindDef(testCoreKindFS(`@kindsys(framework="v0")`+"\n\n", "experimental"), "kind", nil)
+		require.NoError(t, err)
+		require.True(t, def.IsCore())
+	})
+
+	t.Run("pinned to newer version", func(t *testing.T) {
+		_, err := LoadKindDef(testCoreKindFS(`@kindsys(framework="v1")`+"\n\n", "experimental"), "kind", nil)
+		require.Error(t, err)
+	})
+
+	t.Run("pinned to unknown version", func(t *testing.T) {
+		_, err := LoadKindDef(testCoreKindFS(`@kindsys(framework="v9")`+"\n\n", "merged"), "kind", nil)
+		require.True(t, errors.Is(err, ErrUnknownFrameworkVersion), err)
+	})
+
+	t.Run("pinned with other arguments", func(t *testing.T) {
+		def, err := LoadKindDef(testCoreKindFS(`@kindsys(note="a, b", framework="v0")`+"\n\n", "experimental"), "kind", nil)
+		require.NoError(t, err)
+		require.True(t, def.IsCore())
+	})
+
+	t.Run("malformed pin", func(t *testing.T) {
+		_, err := LoadKindDef(testCoreKindFS(`@kindsys(framework="v0"x)`+"\n\n", "merged"), "kind", nil)
+		require.ErrorContains(t, err, "malformed attribute")
+		_, err = LoadKindDef(testCoreKindFS(`@kindsys(framework="")`+"\n\n", "merged"), "kind", nil)
+		require.ErrorContains(t, err, "empty framework version")
+	})
+
+	t.Run("conflicting pins", func(t *testing.T) {
+		fsys := testCoreKindFS(`@kindsys(framework="v0")`+"\n\n", "merged")
+		fsys["kind/other.cue"] = &fstest.MapFile{Data: []byte("@kindsys(framework=\"v1\")\n\npackage kind\n")}
+		_, err := LoadKindDef(fsys, "kind", nil)
+		require.ErrorContains(t, err, "multiple kindsys framework versions")
+	})
+}
+
+func TestCheckFrameworkMigration(t *testing.T) {
+	withStricterFramework(t)
+
+	m, err := CheckFrameworkMigration(testCoreKindFS(`@kindsys(framework="v0")`+"\n\n", "experimental"), "kind", nil)
+	require.NoError(t, err)
+	require.Equal(t, "v0", m.From)
+	require.Len(t, m.Results, 1)
+	require.Equal(t, "v1", m.Results[0].Version)
+	require.Error(t, m.Results[0].Err)
+	require.False(t, m.Ok())
+	require.Contains(t, m.String(), "targets kindsys framework v0")
+
+	m, err = CheckFrameworkMigration(testCoreKindFS(`@kindsys(framework="v0")`+"\n\n", "merged"), "kind", nil)
+	require.NoError(t, err)
+	require.True(t, m.Ok(), m.String())
+
+	m, err = CheckFrameworkMigration(testCoreKindFS("", "merged"), "kind", nil)
+	require.NoError(t, err)
+	require.Equal(t, "v1", m.From)
+	require.Empty(t, m.Results)
+}
+
+func TestToDefUsesLatestFramework(t *testing.T) {
+	v := ctx.CompileString(`
+name:        "Kind"
+maturity:    "experimental"
+description: "A core kind for tests."
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		spec: title: string
+	}
+}]
+`)
+	_, err := ToDef[CoreProperties](v)
+	require.NoError(t, err)
+
+	withStricterFramework(t)
+	_, err = ToDef[CoreProperties](v)
+	require.ErrorContains(t, err, `conflicting values "merged" and "experimental"`)
+	_, err = ToSomeDef(v)
+	require.Error(t, err)
+}
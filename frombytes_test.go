@@ -56,3 +56,215 @@ lineage: schemas: [{
 	require.Equal(t, "you", res.CommonMeta.UpdatedBy)
 	require.Equal(t, "2023-07-06T03:08:01Z", res.CommonMeta.UpdateTimestamp.Format(time.RFC3339))
 }
+
+type testResource struct {
+	BasicMetadataObject
+	Spec struct {
+		ASpecField int32 `json:"aSpecField"`
+	} `json:"spec"`
+	Status struct {
+		OperatorStates   map[string]any `json:"operatorStates,omitempty"`
+		AdditionalFields map[string]any `json:"additionalFields,omitempty"`
+	} `json:"status"`
+}
+
+func (r *testResource) SpecObject() any { return r.Spec }
+
+func (r *testResource) Subresources() map[string]any {
+	return map[string]any{"status": r.Status}
+}
+
+func (r *testResource) Copy() Resource { return CopyResource(r) }
+
+// mismatchedResource is a testResource whose spec does not match TestKind.
+type mismatchedResource struct {
+	testResource
+	Spec struct {
+		ASpecField string `json:"aSpecField"`
+	} `json:"spec"`
+}
+
+// speclessResource is a testResource without a spec.
+type speclessResource struct {
+	BasicMetadataObject
+}
+
+func (r *speclessResource) SpecObject() any { return nil }
+
+func (r *speclessResource) Subresources() map[string]any { return nil }
+
+func (r *speclessResource) Copy() Resource { return CopyResource(r) }
+
+func TestTypeFromBytes(t *testing.T) {
+	var testkind = `
+name: "TestKind"
+description: "Blammo!"
+maturity: "experimental"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		spec: aSpecField: int32
+		// a field whose name must be quoted
+		"a-b"?: string
+	}
+}]
+`
+
+	var testresource = `
+{
+	"apiVersion": "testkind.core.grafana.com/v0-0",
+	"kind": "TestKind",
+	"metadata": {
+		"name": "test",
+		"namespace": "default",
+		"annotations": {
+			"grafana.com/createdBy": "me"
+		}
+	},
+	"spec": {
+		"aSpecField": 42
+	},
+	"status": {
+		"additionalFields": {"foo": "bar"}
+	}
+}`
+
+	rt := thema.NewRuntime(ctx)
+	def, err := ToDef[CoreProperties](ctx.CompileString(testkind))
+	require.NoError(t, err)
+	k, err := BindCore(rt, def)
+	require.NoError(t, err)
+
+	res, err := k.FromBytes([]byte(testresource), &encoding.KubernetesJSONDecoder{})
+	require.NoError(t, err)
+	require.Equal(t, "v0-0", res.StaticMeta.Version)
+	require.EqualValues(t, 42, res.Spec["aSpecField"])
+	require.Equal(t, map[string]any{"additionalFields": map[string]any{"foo": "bar"}}, res.Status)
+
+	_, err = BindCoreResource[Resource](k)
+	require.ErrorContains(t, err, "not a pointer to a struct")
+	_, err = BindCoreResource[*mismatchedResource](k)
+	require.ErrorContains(t, err, "spec.aSpecField: is kind int in schema, but kind string in Go type")
+	_, err = BindCoreResource[*speclessResource](k)
+	require.ErrorContains(t, err, "has no spec field")
+
+	tk, err := BindCoreResource[*testResource](k)
+	require.NoError(t, err)
+	tres, err := tk.TypeFromBytes([]byte(testresource), &encoding.KubernetesJSONDecoder{})
+	require.NoError(t, err)
+	require.Equal(t, "TestKind", tres.StaticMeta.Kind)
+	require.Equal(t, "me", tres.CommonMeta.CreatedBy)
+	require.EqualValues(t, 42, tres.Spec.ASpecField)
+	require.Equal(t, map[string]any{"foo": "bar"}, tres.Status.AdditionalFields)
+
+	_, err = tk.TypeFromBytes([]byte(`{"kind": "TestKind", "spec": {"aSpecField": "no"}}`), &encoding.KubernetesJSONDecoder{})
+	require.Error(t, err)
+}
+
+func TestComposableTypeFromBytes(t *testing.T) {
+	var panelkind = `
+name: "TestPanelCfg"
+maturity: "experimental"
+schemaInterface: "PanelCfg"
+lineage: name: "testpanelcfg"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		Options: {
+			mode: "markdown" | "html" | *"markdown"
+			content: string
+		}
+		FieldConfig?: {
+			hideFrom?: bool
+		}
+		// a member whose name must be quoted
+		"x-extra"?: string
+	}
+}]
+`
+
+	type options struct {
+		Mode    string `json:"mode"`
+		Content string `json:"content"`
+	}
+
+	rt := thema.NewRuntime(ctx)
+	def, err := ToDef[ComposableProperties](ctx.CompileString(panelkind))
+	require.NoError(t, err)
+	k, err := BindComposable(rt, def)
+	require.NoError(t, err)
+
+	_, err = BindComposableType[*options](k, "")
+	require.ErrorContains(t, err, "member must be named")
+	_, err = BindComposableType[*options](k, "Nope")
+	require.ErrorContains(t, err, "no member Nope")
+	_, err = BindComposableType[*struct {
+		Mode int `json:"mode"`
+	}](k, "Options")
+	require.Error(t, err)
+	_, err = BindComposableType[string](k, "Options")
+	require.ErrorContains(t, err, "not a struct")
+
+	tk, err := BindComposableType[*options](k, "Options")
+	require.NoError(t, err)
+	require.Equal(t, "Options", tk.Member())
+
+	opts, err := tk.TypeFromBytes([]byte(`{"content": "# Hello"}`))
+	require.NoError(t, err)
+	require.Equal(t, &options{Mode: "markdown", Content: "# Hello"}, opts)
+
+	_, err = tk.TypeFromBytes([]byte(`{"mode": "text", "content": "# Hello"}`))
+	require.Error(t, err)
+	_, err = tk.TypeFromBytes([]byte(`{"mode": "html"}`))
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, []FieldError{{Path: "content", Message: "missing required field of type string"}}, verr.Fields)
+
+	type fieldConfig struct {
+		HideFrom *bool `json:"hideFrom,omitempty"`
+	}
+	tf, err := BindComposableType[fieldConfig](k, "FieldConfig")
+	require.NoError(t, err)
+	fc, err := tf.TypeFromBytes([]byte(`{"hideFrom": true}`))
+	require.NoError(t, err)
+	require.True(t, *fc.HideFrom)
+
+	var querykind = `
+name: "TestDataQuery"
+maturity: "experimental"
+schemaInterface: "DataQuery"
+lineage: name: "testdataquery"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		refId: string
+		expr?: string
+	}
+}]
+`
+
+	type query struct {
+		RefID string  `json:"refId"`
+		Expr  *string `json:"expr,omitempty"`
+	}
+
+	def, err = ToDef[ComposableProperties](ctx.CompileString(querykind))
+	require.NoError(t, err)
+	k, err = BindComposable(rt, def)
+	require.NoError(t, err)
+
+	_, err = BindComposableType[query](k, "Options")
+	require.ErrorContains(t, err, "not a group")
+
+	tq, err := BindComposableType[query](k, "")
+	require.NoError(t, err)
+	require.Equal(t, "", tq.Member())
+
+	q, err := tq.TypeFromBytes([]byte(`{"refId": "A", "expr": "up"}`))
+	require.NoError(t, err)
+	require.Equal(t, "A", q.RefID)
+	require.Equal(t, "up", *q.Expr)
+
+	_, err = tq.TypeFromBytes([]byte(`{"expr": "up"}`))
+	require.Error(t, err)
+}
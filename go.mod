@@ -9,6 +9,7 @@ require (
 	github.com/grafana/cuetsy v0.1.11
 	github.com/grafana/thema v0.0.0-20230801151112-711d7fd5162f
 	github.com/matryer/is v1.4.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/stretchr/testify v1.8.2
 	github.com/yalue/merged_fs v1.2.2
 	k8s.io/apimachinery v0.26.2
@@ -49,7 +50,6 @@ require (
 	github.com/mpvl/unique v0.0.0-20150818121801-cbe035fff7de // indirect
 	github.com/perimeterx/marshmallow v1.1.4 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/protocolbuffers/txtpbfmt v0.0.0-20230730201308-0c31dbd32b9f // indirect
 	github.com/rogpeppe/go-internal v1.11.0 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
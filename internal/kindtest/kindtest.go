@@ -0,0 +1,36 @@
+// Package kindtest provides helpers for tests that operate on kinds.
+package kindtest
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/grafana/thema"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/kindsys"
+)
+
+// BindCore loads and binds a core kind from src, the fields of a kind
+// definition that embeds kindsys.Core, such as its name, maturity and lineage.
+// The kind is loaded as by [kindsys.LoadCoreKindDef], in a new CUE context,
+// and bound with opts. The test fails if either step does.
+func BindCore(t *testing.T, src string, opts ...thema.BindOption) kindsys.Core {
+	t.Helper()
+	fsys := fstest.MapFS{
+		"kind/kind.cue": &fstest.MapFile{Data: []byte(`package kind
+
+import "github.com/grafana/kindsys"
+
+kindsys.Core
+` + src + "\n")},
+	}
+
+	ctx := cuecontext.New()
+	def, err := kindsys.LoadCoreKindDef(fsys, "kind", ctx)
+	require.NoError(t, err)
+	k, err := kindsys.BindCore(thema.NewRuntime(ctx), def, opts...)
+	require.NoError(t, err)
+	return k
+}
@@ -86,7 +86,7 @@ func (m Maturity) String() string {
 //
 // - [Core] -> [TypedCore]
 // - [Custom] -> [TypedCustom]
-// - [Composable] -> [TypedComposable] (TODO not yet implemented)
+// - [Composable] -> [TypedComposable]
 //
 // Each embeds the corresponding untyped interface, and takes a generic type
 // parameter. The provided struct is verified to be assignable to the latest
@@ -97,6 +97,15 @@ func (m Maturity) String() string {
 // Directly implementing this interface is discouraged. Strongly prefer instead to
 // rely on [BindCore], [BindCustom], or [BindComposable].
 //
+// Kinds returned from those funcs are safe for concurrent use. CUE itself is
+// not, so all CUE operations performed by methods such as Validate and
+// FromBytes are serialized on the [cue.Context] of the [thema.Runtime] the kind
+// was bound with. Kinds sharing a runtime contend for that lock; if many
+// goroutines hammer a handful of kinds, bind each kind with its own runtime
+// (and cue.Context), as [LoadKinds] does. Values obtained directly from a kind,
+// such as from Lineage or Def, are not guarded, and must not be used
+// concurrently without the caller providing its own synchronization.
+//
 // [the kindsys repository]: https://github.com/grafana/kindsys
 type Kind interface {
 	// Name returns the kind's name, as specified in the name field of the kind definition.
@@ -201,6 +210,32 @@ type Composable interface {
 	// Def returns a wrapper around the underlying CUE value that represents the
 	// loaded and validated kind definition.
 	Def() Def[ComposableProperties]
+
+	// Validate checks that the JSON-encoded object in b, such as a datasource's
+	// query or a panel's Options, is valid against a schema in the kind's
+	// lineage.
+	//
+	// The member of the schemas against which the object is validated, and the
+	// version of the schema, are chosen by cfg. If no version is chosen, the
+	// object is valid if it is valid against any schema in the lineage.
+	//
+	// If the object is invalid, the returned error is a [*ValidationError]
+	// describing each invalid field.
+	Validate(b []byte, cfg ComposableValidateConfig) error
+}
+
+// ComposableValidateConfig holds options for [Composable.Validate].
+type ComposableValidateConfig struct {
+	// Member is the name of the top-level field of the kind's schemas, such as
+	// "Options", against which the object is validated. It is required if the
+	// kind's lineage is a group (see [SchemaInterface.IsGroup]), and must be
+	// empty otherwise.
+	Member string
+
+	// Version, if non-nil, is the version of the schema against which the
+	// object is validated. If nil, the object is validated against the kind's
+	// current schema, then against each other schema containing Member in turn.
+	Version *thema.SyntacticVersion
 }
 
 // TypedCore is the statically typed runtime representation of a Grafana core
@@ -235,6 +270,34 @@ type TypedCustom[R Resource] interface {
 	TypeFromBytes(b []byte, codec Decoder) (R, error)
 }
 
+// TypedComposable is the statically typed runtime representation of a Grafana
+// composable kind definition. It is one in a family of interfaces, see [Kind]
+// for context.
+//
+// A TypedComposable provides typed interactions with the Go type given as its
+// generic type parameter, which represents the objects a composable kind
+// describes, such as a datasource's query. Where the kind's lineage is a group
+// (see [SchemaInterface.IsGroup]), the type instead represents a single member
+// of the group, such as a panel's Options. As it embeds [Composable], untyped
+// interaction is also available.
+//
+// A TypedComposable is created by calling [BindComposableType] on a [Composable]
+// with a Go type to which the object is assignable (see [thema.BindType]).
+type TypedComposable[T any] interface {
+	Composable
+
+	// Member returns the name of the top-level field of the kind's schemas
+	// that represents the objects of type T, or the empty string if the kind's
+	// lineage is not a group.
+	Member() string
+
+	// TypeFromBytes validates the JSON-encoded object in b against the kind's
+	// current schema, and returns it as an instance of the associated generic
+	// type, with defaults from the schema filled in. If the object is invalid,
+	// the returned error is a [*ValidationError].
+	TypeFromBytes(b []byte) (T, error)
+}
+
 // Decoder takes a []byte representing a serialized resource and decodes it into
 // the intermediate [encoding.GrafanaShapeBytes] form. Implementations should
 // vary in the form of the []byte they expect to take - e.g. JSON vs. YAML;
@@ -20,11 +20,14 @@ Composable: S={
 //	schemaInterface: or([ for k, _ in schemaInterfaces {k}, string])
 
 	let schif = schemaInterfaces[S.schemaInterface]
+	// _schemaInterface exposes schif to Go, which cannot look up a let.
+	_schemaInterface: schif
 
 	// lineage is the Thema lineage containing all the schemas that have existed for this kind.
 	// The name of the lineage is constrained to the name of the schema interface being implemented.
 // FIXME cuetsy currently gets confused by all the unifications - maybe openapi too. Do something like the following after thema separates joinSchema/constraint expression
 //	lineage: { joinSchema: schif.interface }
+// Until then, BindComposable checks in Go that each schema in the lineage satisfies _schemaInterface.interface.
 
 	lineageIsGroup: schif.group
 }
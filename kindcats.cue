@@ -71,12 +71,10 @@ _sharedKind: {
 	// schema in lineage.
 	currentVersion: lineage.#LatestVersion
 
-	maturity: Maturity
-
-	// The kind system itself is not mature enough yet for any single
-	// kind to advance beyond "experimental"
-	// TODO allow more maturity stages once system is ready https://github.com/orgs/grafana/projects/133/views/8
-	maturity: *"merged" | "experimental"
+	// maturity indicates how far the kind is in its initial journey. The
+	// guarantees of "stable" and "mature" are enforced by the Go policy engine
+	// in pkg/policy, rather than here.
+	maturity: Maturity | *"merged"
 }
 
 // properties shared by all kinds that represent a complete object from root (i.e., not composable)
@@ -120,7 +118,7 @@ Core: S=close({
 
 		// deepCopy determines whether a generic implementation of copying should be
 		// generated, or a passthrough call to a Go function.
-		//   deepCopy: *"generic" | "passthrough"
+		deepCopy: *"generic" | "passthrough"
 	}
 })
 
@@ -2,10 +2,13 @@ package kindsys
 
 import (
 	"fmt"
+	"io"
 	"io/fs"
+	"path"
 	"path/filepath"
+	"strings"
 	"sync"
-	"testing/fstest"
+	"time"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/build"
@@ -31,7 +34,7 @@ func init() {
 func loadpFrameworkOnce() {
 	fwOnce.Do(func() {
 		var err error
-		defaultFramework, err = doLoadFrameworkCUE(cueContext())
+		defaultFramework, err = doLoadFrameworkCUE(cueContext(), CueSchemaFS)
 		if err != nil {
 			panic(err)
 		}
@@ -39,8 +42,10 @@ func loadpFrameworkOnce() {
 	})
 }
 
-func doLoadFrameworkCUE(ctx *cue.Context) (cue.Value, error) {
-	v, err := BuildInstance(ctx, ".", "kindsys", nil)
+// doLoadFrameworkCUE builds the kindsys framework CUE package at the root of
+// fwfs, which is laid out like [CueSchemaFS].
+func doLoadFrameworkCUE(ctx *cue.Context, fwfs fs.FS) (cue.Value, error) {
+	v, err := buildInstance(ctx, ".", "kindsys", nil, fwfs)
 	if err != nil {
 		return v, err
 	}
@@ -53,7 +58,13 @@ func doLoadFrameworkCUE(ctx *cue.Context) (cue.Value, error) {
 }
 
 func BuildInstance(ctx *cue.Context, relpath string, pkg string, overlay fs.FS) (cue.Value, error) {
-	bi, err := LoadInstance(relpath, pkg, overlay)
+	return buildInstance(ctx, relpath, pkg, overlay, CueSchemaFS)
+}
+
+// buildInstance is [BuildInstance], but with the kindsys framework CUE files
+// taken from fwfs rather than CueSchemaFS.
+func buildInstance(ctx *cue.Context, relpath string, pkg string, overlay, fwfs fs.FS) (cue.Value, error) {
+	bi, err := loadInstance(relpath, pkg, overlay, fwfs)
 	if err != nil {
 		return cue.Value{}, err
 	}
@@ -62,7 +73,9 @@ func BuildInstance(ctx *cue.Context, relpath string, pkg string, overlay fs.FS)
 		ctx = cueContext()
 	}
 
+	unlock := lockCUE(ctx)
 	v := ctx.BuildInstance(bi)
+	unlock()
 	if v.Err() != nil {
 		return v, fmt.Errorf("%s not a valid CUE instance: %w", relpath, v.Err())
 	}
@@ -72,12 +85,16 @@ func BuildInstance(ctx *cue.Context, relpath string, pkg string, overlay fs.FS)
 // LoadInstance returns a build.Instance populated with the CueSchemaFS at the root and
 // an optional overlay filesystem.
 func LoadInstance(relpath string, pkg string, overlay fs.FS) (*build.Instance, error) {
+	return loadInstance(relpath, pkg, overlay, CueSchemaFS)
+}
+
+func loadInstance(relpath string, pkg string, overlay, fwfs fs.FS) (*build.Instance, error) {
 	relpath = filepath.ToSlash(relpath)
 
-	var f fs.FS = CueSchemaFS
+	f := fwfs
 	var err error
 	if overlay != nil {
-		f, err = prefixWithCUE(relpath, overlay)
+		f, err = prefixWithCUE(relpath, overlay, fwfs)
 		if err != nil {
 			return nil, err
 		}
@@ -89,46 +106,236 @@ func LoadInstance(relpath string, pkg string, overlay fs.FS) (*build.Instance, e
 	return load.InstanceWithThema(f, relpath)
 }
 
-// prefixWithCUE constructs an fs.FS that merges the provided fs.FS with the
-// embedded FS containing kindsys core CUE files, CueSchemaFS. The provided
+// prefixWithCUE constructs an fs.FS that merges the provided fs.FS with an FS
+// containing kindsys core CUE files, usually CueSchemaFS. The provided
 // prefix should be the relative path from the repository root to the directory
 // root of the provided inputfs.
 //
 // The returned fs.FS is suitable for passing to a CUE loader, such as
 // [load.InstanceWithThema].
-func prefixWithCUE(prefix string, inputfs fs.FS) (fs.FS, error) {
+func prefixWithCUE(prefix string, inputfs, fwfs fs.FS) (fs.FS, error) {
 	m, err := prefixFS(prefix, inputfs)
 	if err != nil {
 		return nil, err
 	}
-	return merged_fs.NewMergedFS(m, CueSchemaFS), nil
+	return merged_fs.NewMergedFS(m, fwfs), nil
 }
 
-// TODO such a waste, replace with stateless impl that just transforms paths on the fly
+// prefixFS returns an fs.FS that presents the contents of fsys as though they
+// were located under the directory prefix. Paths are mapped on the fly; no
+// file contents are read or copied.
 func prefixFS(prefix string, fsys fs.FS) (fs.FS, error) {
-	m := make(fstest.MapFS)
+	prefix = path.Clean(filepath.ToSlash(prefix))
+	if !fs.ValidPath(prefix) {
+		return nil, &fs.PathError{Op: "prefix", Path: prefix, Err: fs.ErrInvalid}
+	}
+	if prefix == "." {
+		return fsys, nil
+	}
+	return &prefixedFS{prefix: prefix, fsys: fsys}, nil
+}
+
+// prefixedFS is the fs.FS returned from prefixFS. Paths at or below prefix
+// are passed through to fsys with prefix trimmed. The ancestors of prefix are
+// synthesized as directories, each containing only the next element of prefix.
+type prefixedFS struct {
+	prefix string
+	fsys   fs.FS
+}
+
+var (
+	_ fs.ReadDirFS = &prefixedFS{}
+	_ fs.StatFS    = &prefixedFS{}
+)
+
+// inner maps name to the corresponding path in the wrapped fs.FS, if name is
+// at or below prefix.
+func (p *prefixedFS) inner(name string) (string, bool) {
+	if name == p.prefix {
+		return ".", true
+	}
+	if strings.HasPrefix(name, p.prefix+"/") {
+		return name[len(p.prefix)+1:], true
+	}
+	return "", false
+}
 
-	prefix = filepath.FromSlash(prefix)
-	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+// child returns the sole entry of the synthetic directory name, if name is
+// an ancestor of prefix.
+func (p *prefixedFS) child(name string) (string, bool) {
+	rest := p.prefix
+	if name != "." {
+		if !strings.HasPrefix(p.prefix, name+"/") {
+			return "", false
+		}
+		rest = p.prefix[len(name)+1:]
+	}
+	if i := strings.IndexByte(rest, '/'); i != -1 {
+		rest = rest[:i]
+	}
+	return rest, true
+}
+
+func (p *prefixedFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if in, ok := p.inner(name); ok {
+		f, err := p.fsys.Open(in)
 		if err != nil {
-			return err
+			return nil, p.mapErr(err, name)
+		}
+		if in == "." {
+			return &prefixRoot{File: f, name: path.Base(name)}, nil
 		}
+		return f, nil
+	}
+	if child, ok := p.child(name); ok {
+		ent, err := p.childEntry(name, child)
+		if err != nil {
+			return nil, err
+		}
+		return &syntheticDir{name: name, ent: ent}, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
 
-		if d.IsDir() {
-			return nil
+func (p *prefixedFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	if in, ok := p.inner(name); ok {
+		ents, err := fs.ReadDir(p.fsys, in)
+		return ents, p.mapErr(err, name)
+	}
+	if child, ok := p.child(name); ok {
+		ent, err := p.childEntry(name, child)
+		if err != nil {
+			return nil, err
 		}
+		return []fs.DirEntry{ent}, nil
+	}
+	return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+}
 
-		b, err := fs.ReadFile(fsys, filepath.ToSlash(path))
+func (p *prefixedFS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	if in, ok := p.inner(name); ok {
+		fi, err := fs.Stat(p.fsys, in)
 		if err != nil {
-			return err
+			return nil, p.mapErr(err, name)
 		}
-		// fstest can recognize only forward slashes.
-		m[filepath.ToSlash(filepath.Join(prefix, path))] = &fstest.MapFile{Data: b}
-		return nil
-	})
-	return m, err
+		if in == "." {
+			return renamedInfo{FileInfo: fi, name: path.Base(name)}, nil
+		}
+		return fi, nil
+	}
+	if _, ok := p.child(name); ok {
+		return syntheticDirInfo(path.Base(name)), nil
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+// childEntry returns the entry for child within the synthetic directory dir.
+// The entry for the last element of prefix describes the root of the wrapped
+// fs.FS.
+func (p *prefixedFS) childEntry(dir, child string) (fs.DirEntry, error) {
+	if path.Join(dir, child) != p.prefix {
+		return fs.FileInfoToDirEntry(syntheticDirInfo(child)), nil
+	}
+	fi, err := fs.Stat(p.fsys, ".")
+	if err != nil {
+		return nil, p.mapErr(err, p.prefix)
+	}
+	return fs.FileInfoToDirEntry(renamedInfo{FileInfo: fi, name: child}), nil
+}
+
+// mapErr rewrites the path in errors from the wrapped fs.FS to the path that
+// was requested from the prefixedFS.
+func (p *prefixedFS) mapErr(err error, name string) error {
+	var perr *fs.PathError
+	if errors.As(err, &perr) {
+		return &fs.PathError{Op: perr.Op, Path: name, Err: perr.Err}
+	}
+	return err
+}
+
+// prefixRoot is the open root directory of the fs.FS wrapped by a
+// prefixedFS, named as the last element of the prefix.
+type prefixRoot struct {
+	fs.File
+	name string
+}
+
+func (r *prefixRoot) Stat() (fs.FileInfo, error) {
+	fi, err := r.File.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return renamedInfo{FileInfo: fi, name: r.name}, nil
+}
+
+func (r *prefixRoot) ReadDir(n int) ([]fs.DirEntry, error) {
+	rdf, ok := r.File.(fs.ReadDirFile)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: r.name, Err: fs.ErrInvalid}
+	}
+	return rdf.ReadDir(n)
+}
+
+// renamedInfo overrides the name of an fs.FileInfo.
+type renamedInfo struct {
+	fs.FileInfo
+	name string
+}
+
+func (i renamedInfo) Name() string {
+	return i.name
 }
 
+// syntheticDir is an open ancestor directory of a prefixedFS's prefix.
+type syntheticDir struct {
+	name string
+	ent  fs.DirEntry
+	read bool
+}
+
+func (d *syntheticDir) Stat() (fs.FileInfo, error) {
+	return syntheticDirInfo(path.Base(d.name)), nil
+}
+
+func (d *syntheticDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: fs.ErrInvalid}
+}
+
+func (d *syntheticDir) Close() error {
+	return nil
+}
+
+func (d *syntheticDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if d.read {
+		if n > 0 {
+			return nil, io.EOF
+		}
+		return nil, nil
+	}
+	d.read = true
+	return []fs.DirEntry{d.ent}, nil
+}
+
+// syntheticDirInfo is the fs.FileInfo for a synthetic directory with the
+// given base name.
+type syntheticDirInfo string
+
+func (i syntheticDirInfo) Name() string       { return string(i) }
+func (i syntheticDirInfo) Size() int64        { return 0 }
+func (i syntheticDirInfo) Mode() fs.FileMode  { return fs.ModeDir | 0o555 }
+func (i syntheticDirInfo) ModTime() time.Time { return time.Time{} }
+func (i syntheticDirInfo) IsDir() bool        { return true }
+func (i syntheticDirInfo) Sys() any           { return nil }
+
 // CUEFramework returns a cue.Value representing all the kindsys framework raw
 // CUE files.
 //
@@ -147,83 +354,116 @@ func CUEFramework(ctx *cue.Context) cue.Value {
 		return defaultFramework
 	}
 	// Error guaranteed to be nil here because erroring would have caused init() to panic
-	v, _ := doLoadFrameworkCUE(ctx) // nolint:errcheck
+	v, _ := doLoadFrameworkCUE(ctx, CueSchemaFS) // nolint:errcheck
 	return v
 }
 
 // ToKindProps takes a cue.Value expected to represent a kind of the category
 // specified by the type parameter and populates the Go type from the cue.Value.
 func ToKindProps[T KindProperties](v cue.Value) (T, error) {
-	props := new(T)
-	if !v.Exists() {
-		return *props, ErrValueNotExist
-	}
-
-	fw := CUEFramework(v.Context())
-	var kdef cue.Value
-
-	anyprops := any(*props).(SomeKindProperties)
-	switch anyprops.(type) {
-	case CoreProperties:
-		kdef = fw.LookupPath(cue.MakePath(cue.Str("Core")))
-	case CustomProperties:
-		kdef = fw.LookupPath(cue.MakePath(cue.Str("Custom")))
-	case ComposableProperties:
-		kdef = fw.LookupPath(cue.MakePath(cue.Str("Composable")))
-	default:
-		// unreachable so long as all the possibilities in KindProperties have switch branches
-		panic("unreachable")
-	}
-
-	item := v.Unify(kdef)
-	if item.Err() != nil {
-		return *props, errors.Wrap(errors.Promote(ErrValueNotAKind, ""), item.Err())
-	}
-
-	if err := item.Decode(props); err != nil {
-		// Should only be reachable if CUE and Go framework types have diverged
-		panic(errors.Details(err, nil))
-	}
-
-	return *props, nil
+	def, err := ToDef[T](v)
+	return def.Properties, err
 }
 
 // ToDef takes a cue.Value expected to represent a kind of the category
 // specified by the type parameter and populates a Def from the CUE value.
 // The cue.Value in Def.V will be the unified value of the parameter cue.Value
-// and the kindsys CUE kind (Core, Custom, Composable).
+// and the kindsys CUE kind (Core, Custom, Composable). If the parameter
+// cue.Value already embeds that kindsys CUE kind, as kind definitions loaded
+// by [LoadCoreKindDef] and friends usually do, it is used as Def.V unchanged.
+// Otherwise, the kind is taken from the latest of [FrameworkVersions].
 func ToDef[T KindProperties](v cue.Value) (Def[T], error) {
+	return toDef[T](v, latestFramework())
+}
+
+// toDef is [ToDef], but unifies with the kind category from the specified
+// version of the kindsys CUE framework. Values embedding their category are
+// used as-is, having already been built against the framework version they
+// import.
+func toDef[T KindProperties](v cue.Value, fwversion string) (Def[T], error) {
 	def := Def[T]{}
 	props := new(T)
 	if !v.Exists() {
 		return def, ErrValueNotExist
 	}
 
-	fw := CUEFramework(v.Context())
-	var kdef cue.Value
+	cat := categoryName[T]()
+	unlock := lockCUE(v.Context())
+	embedded := embeddedCategory(v) == cat
+	unlock()
+
+	var fw cue.Value
+	if !embedded {
+		var err error
+		if fw, err = CUEFrameworkVersion(v.Context(), fwversion); err != nil {
+			return def, err
+		}
+	}
+
+	defer lockCUE(v.Context())()
+	def.V = v
+	if fw.Exists() {
+		def.V = v.Unify(fw.LookupPath(cue.MakePath(cue.Str(cat))))
+	}
+	if def.V.Err() != nil {
+		return def, errors.Wrap(errors.Promote(ErrValueNotAKind, ""), def.V.Err())
+	}
+
+	if err := def.V.Decode(props); err != nil {
+		// Reachable if the value is incomplete with respect to the category,
+		// e.g. a Core kind checked as a Custom kind, which lacks a group.
+		return def, errors.Wrap(errors.Promote(ErrValueNotAKind, ""), err)
+	}
+	def.Properties = *props
+	return def, nil
+}
 
-	anyprops := any(*props).(SomeKindProperties)
-	switch anyprops.(type) {
+// categoryName returns the name of the kindsys CUE definition corresponding
+// to the kind category specified by the type parameter.
+func categoryName[T KindProperties]() string {
+	switch any(*new(T)).(type) {
 	case CoreProperties:
-		kdef = fw.LookupPath(cue.MakePath(cue.Str("Core")))
+		return "Core"
 	case CustomProperties:
-		kdef = fw.LookupPath(cue.MakePath(cue.Str("Custom")))
+		return "Custom"
 	case ComposableProperties:
-		kdef = fw.LookupPath(cue.MakePath(cue.Str("Composable")))
+		return "Composable"
 	default:
 		// unreachable so long as all the possibilities in KindProperties have switch branches
 		panic("unreachable")
 	}
+}
 
-	def.V = v.Unify(kdef)
-	if def.V.Err() != nil {
-		return def, errors.Wrap(errors.Promote(ErrValueNotAKind, ""), def.V.Err())
+// embeddedCategory returns the name of the kindsys CUE kind category
+// definition (Core, Custom, Composable) embedded at the root of the provided
+// cue.Value, as in:
+//
+//	import "github.com/grafana/kindsys"
+//
+//	kindsys.Core
+//	name: "Folder"
+//
+// An empty string is returned if no such embedding is found.
+func embeddedCategory(v cue.Value) string {
+	op, args := v.Expr()
+	if op != cue.AndOp {
+		args = []cue.Value{v}
 	}
 
-	if err := def.V.Decode(props); err != nil {
-		// Should only be reachable if CUE and Go framework types have diverged
-		panic(errors.Details(err, nil))
+	for _, arg := range args {
+		root, p := arg.ReferencePath()
+		if !root.Exists() || len(p.Selectors()) != 1 {
+			continue
+		}
+		if bi := root.BuildInstance(); bi == nil || bi.ImportPath != frameworkImportPath {
+			continue
+		}
+		switch sel := p.Selectors()[0].String(); sel {
+		case "Core", "Custom", "Composable":
+			return sel
+		}
 	}
-	def.Properties = *props
-	return def, nil
+	return ""
 }
+
+const frameworkImportPath = "github.com/grafana/kindsys"
@@ -0,0 +1,224 @@
+package kindsys
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/parser"
+)
+
+// LoadCoreKindDef loads and validates a Core kind definition from the CUE
+// package in the directory at defpath within fsys.
+//
+// The kindsys CUE framework is overlaid automatically, so the kind's .cue
+// files may `import "github.com/grafana/kindsys"` without fsys containing a
+// cue.mod directory. Only the .cue files under defpath are loaded.
+//
+// The kind is loaded against the framework version it declares with a
+// `@kindsys(framework="...")` file attribute, or the latest of
+// [FrameworkVersions] if it declares none. See [FrameworkVersions].
+//
+// Passing a nil [cue.Context] uses the package singleton. See [CUEFramework].
+func LoadCoreKindDef(fsys fs.FS, defpath string, ctx *cue.Context) (Def[CoreProperties], error) {
+	v, fwversion, err := loadKindValue(fsys, defpath, ctx, "")
+	if err != nil {
+		return Def[CoreProperties]{}, err
+	}
+	def, err := toDef[CoreProperties](v, fwversion)
+	if err != nil {
+		return def, err
+	}
+	def.Dir = cleanDefPath(defpath)
+	return def, nil
+}
+
+// LoadCustomKindDef loads and validates a Custom kind definition from the CUE
+// package in the directory at defpath within fsys.
+//
+// See [LoadCoreKindDef] for details on how the CUE package is loaded.
+func LoadCustomKindDef(fsys fs.FS, defpath string, ctx *cue.Context) (Def[CustomProperties], error) {
+	v, fwversion, err := loadKindValue(fsys, defpath, ctx, "")
+	if err != nil {
+		return Def[CustomProperties]{}, err
+	}
+	def, err := toDef[CustomProperties](v, fwversion)
+	if err != nil {
+		return def, err
+	}
+	def.Dir = cleanDefPath(defpath)
+	return def, nil
+}
+
+// LoadComposableKindDef loads and validates a Composable kind definition from
+// the CUE package in the directory at defpath within fsys.
+//
+// See [LoadCoreKindDef] for details on how the CUE package is loaded.
+func LoadComposableKindDef(fsys fs.FS, defpath string, ctx *cue.Context) (Def[ComposableProperties], error) {
+	v, fwversion, err := loadKindValue(fsys, defpath, ctx, "")
+	if err != nil {
+		return Def[ComposableProperties]{}, err
+	}
+	def, err := toDef[ComposableProperties](v, fwversion)
+	if err != nil {
+		return def, err
+	}
+	def.Dir = cleanDefPath(defpath)
+	return def, nil
+}
+
+// LoadKindDef loads and validates a kind definition of any category from the
+// CUE package in the directory at defpath within fsys. The category is
+// determined from the definition itself by [ToSomeDef], and is reflected in the
+// underlying type of the returned SomeDef's Properties.
+//
+// See [LoadCoreKindDef] for details on how the CUE package is loaded.
+func LoadKindDef(fsys fs.FS, defpath string, ctx *cue.Context) (SomeDef, error) {
+	v, fwversion, err := loadKindValue(fsys, defpath, ctx, "")
+	if err != nil {
+		return SomeDef{}, err
+	}
+
+	def, err := toSomeDef(v, fwversion)
+	if err != nil {
+		return SomeDef{}, fmt.Errorf("%s: %w", defpath, err)
+	}
+	def.Dir = cleanDefPath(defpath)
+	return def, nil
+}
+
+// loadKindValue builds the CUE package in the directory at defpath within
+// fsys, with the kindsys framework overlaid.
+//
+// The framework version used is fwversion if non-empty, else the version
+// declared by the package, else the latest. It is returned alongside the value.
+func loadKindValue(fsys fs.FS, defpath string, ctx *cue.Context, fwversion string) (cue.Value, string, error) {
+	if fsys == nil {
+		return cue.Value{}, "", fmt.Errorf("nil fs.FS")
+	}
+	defpath = cleanDefPath(defpath)
+
+	sub := fsys
+	if defpath != "." {
+		var err error
+		if sub, err = fs.Sub(fsys, defpath); err != nil {
+			return cue.Value{}, "", err
+		}
+	}
+
+	pkg, declared, err := inspectKindPackage(sub)
+	if err != nil {
+		return cue.Value{}, "", fmt.Errorf("%s: %w", defpath, err)
+	}
+	switch {
+	case fwversion != "":
+	case declared != "":
+		fwversion = declared
+	default:
+		fwversion = latestFramework()
+	}
+
+	fwfs, err := frameworkFS(fwversion)
+	if err != nil {
+		return cue.Value{}, "", fmt.Errorf("%s: %w", defpath, err)
+	}
+	v, err := buildInstance(ctx, defpath, pkg, sub, fwfs)
+	return v, fwversion, err
+}
+
+// cleanDefPath returns defpath as a clean, slash-separated path.
+func cleanDefPath(defpath string) string {
+	return path.Clean(filepath.ToSlash(defpath))
+}
+
+// inspectKindPackage returns the name of the single CUE package declared by
+// the .cue files at the root of fsys, and the kindsys framework version the
+// package declares it targets, if any.
+func inspectKindPackage(fsys fs.FS) (pkg, fwversion string, err error) {
+	ents, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, ent := range ents {
+		if ent.IsDir() || !strings.HasSuffix(ent.Name(), ".cue") {
+			continue
+		}
+		b, err := fs.ReadFile(fsys, ent.Name())
+		if err != nil {
+			return "", "", err
+		}
+		f, err := parser.ParseFile(ent.Name(), b, parser.PackageClauseOnly)
+		if err != nil {
+			return "", "", errors.Wrap(errors.Promote(ErrInvalidCUE, ""), err)
+		}
+		switch name := f.PackageName(); {
+		case name == "":
+			continue
+		case pkg == "":
+			pkg = name
+		case pkg != name:
+			return "", "", fmt.Errorf("found multiple CUE packages: %s and %s", pkg, name)
+		}
+
+		ver, err := declaredFrameworkVersion(f)
+		if err != nil {
+			return "", "", fmt.Errorf("%s: %w", ent.Name(), err)
+		}
+		switch {
+		case ver == "":
+		case fwversion == "":
+			fwversion = ver
+		case fwversion != ver:
+			return "", "", fmt.Errorf("found multiple kindsys framework versions: %s and %s", fwversion, ver)
+		}
+	}
+
+	if pkg == "" {
+		return "", "", fmt.Errorf("no .cue files with a package clause")
+	}
+	return pkg, fwversion, nil
+}
+
+// declaredFrameworkVersion returns the value of the framework key in the
+// file-level @kindsys attribute of f, or an empty string if there is none.
+func declaredFrameworkVersion(f *ast.File) (string, error) {
+	for _, decl := range f.Decls {
+		attr, ok := decl.(*ast.Attribute)
+		if !ok {
+			continue
+		}
+		if key, _ := attr.Split(); key != "kindsys" {
+			continue
+		}
+
+		// parse the attribute with CUE's own attribute parser by compiling
+		// it as a declaration attribute
+		ctx := cueContext()
+		unlock := lockCUE(ctx)
+		v := ctx.CompileString(attr.Text)
+		attrs := v.Attributes(cue.DeclAttr)
+		unlock()
+		if v.Err() != nil {
+			return "", fmt.Errorf("malformed attribute %s: %w", attr.Text, v.Err())
+		}
+		for _, a := range attrs {
+			ver, found, err := a.Lookup(0, "framework")
+			switch {
+			case err != nil:
+				return "", fmt.Errorf("malformed attribute %s: %w", attr.Text, err)
+			case !found:
+				continue
+			case ver == "":
+				return "", fmt.Errorf("empty framework version in %s", attr.Text)
+			}
+			return ver, nil
+		}
+	}
+	return "", nil
+}
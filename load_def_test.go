@@ -0,0 +1,107 @@
+package kindsys_test
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/kindsys"
+)
+
+var testKindsFS = fstest.MapFS{
+	"kinds/core/core.cue": &fstest.MapFile{Data: []byte(`package core
+
+import "github.com/grafana/kindsys"
+
+kindsys.Core
+name:        "TestCore"
+maturity:    "experimental"
+description: "A core kind for tests."
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		spec: title: string
+	}
+}]
+`)},
+	"kinds/custom/custom.cue": &fstest.MapFile{Data: []byte(`package custom
+
+import "github.com/grafana/kindsys"
+
+kindsys.Custom
+name:  "TestCustom"
+group: "testapp"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		spec: count: int
+	}
+}]
+`)},
+	"kinds/composable/composable.cue": &fstest.MapFile{Data: []byte(`package composable
+
+import "github.com/grafana/kindsys"
+
+kindsys.Composable
+name:            "TestDataQuery"
+schemaInterface: "DataQuery"
+lineage: name:   "testdataquery"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		refId: string
+		expr?: string
+	}
+}]
+`)},
+	"kinds/notakind/notakind.cue": &fstest.MapFile{Data: []byte(`package notakind
+
+foo: "bar"
+`)},
+}
+
+func TestLoadCategoryKindDef(t *testing.T) {
+	ctx := cuecontext.New()
+
+	core, err := kindsys.LoadCoreKindDef(testKindsFS, "kinds/core", ctx)
+	require.NoError(t, err)
+	require.Equal(t, "TestCore", core.Properties.Name)
+	require.Equal(t, "testcore.core.grafana.com", core.Properties.CRD.Group)
+	require.Equal(t, "kinds/core", core.Dir)
+
+	custom, err := kindsys.LoadCustomKindDef(testKindsFS, "kinds/custom", ctx)
+	require.NoError(t, err)
+	require.Equal(t, "testapp", custom.Properties.Group)
+
+	comp, err := kindsys.LoadComposableKindDef(testKindsFS, "kinds/composable", ctx)
+	require.NoError(t, err)
+	require.Equal(t, "DataQuery", comp.Properties.SchemaInterface)
+
+	_, err = kindsys.LoadCoreKindDef(testKindsFS, "kinds/custom", ctx)
+	require.True(t, errors.Is(err, kindsys.ErrValueNotAKind))
+}
+
+func TestLoadKindDef(t *testing.T) {
+	ctx := cuecontext.New()
+
+	tt := map[string]func(kindsys.SomeDef) bool{
+		"kinds/core":       kindsys.SomeDef.IsCore,
+		"kinds/custom":     kindsys.SomeDef.IsCustom,
+		"kinds/composable": kindsys.SomeDef.IsComposable,
+	}
+	for path, is := range tt {
+		def, err := kindsys.LoadKindDef(testKindsFS, path, ctx)
+		require.NoError(t, err, path)
+		require.True(t, is(def), path)
+		require.Equal(t, path, def.Dir)
+	}
+
+	_, err := kindsys.LoadKindDef(testKindsFS, "kinds/notakind", ctx)
+	require.True(t, errors.Is(err, kindsys.ErrValueNotAKind))
+
+	_, err = kindsys.LoadKindDef(testKindsFS, "kinds/nonexistent", ctx)
+	require.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package kindsys
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixFS(t *testing.T) {
+	inner := fstest.MapFS{
+		"kind.cue":         &fstest.MapFile{Data: []byte("package kind")},
+		"sub/other.cue":    &fstest.MapFile{Data: []byte("package sub")},
+		"sub/deep/x.cue":   &fstest.MapFile{Data: []byte("package deep")},
+		"empty/.gitignore": &fstest.MapFile{},
+	}
+
+	for _, prefix := range []string{"a", "a/b/c", filepath.FromSlash("a/b/c/")} {
+		t.Run(prefix, func(t *testing.T) {
+			fsys, err := prefixFS(prefix, inner)
+			require.NoError(t, err)
+
+			p := filepath.ToSlash(filepath.Clean(prefix))
+			require.NoError(t, fstest.TestFS(fsys, p+"/kind.cue", p+"/sub/other.cue", p+"/sub/deep/x.cue", p+"/empty/.gitignore"))
+
+			b, err := fs.ReadFile(fsys, p+"/sub/deep/x.cue")
+			require.NoError(t, err)
+			require.Equal(t, "package deep", string(b))
+
+			_, err = fsys.Open("kind.cue")
+			require.ErrorIs(t, err, fs.ErrNotExist)
+			_, err = fsys.Open(p + "/nope.cue")
+			require.ErrorIs(t, err, fs.ErrNotExist)
+		})
+	}
+
+	fsys, err := prefixFS(".", inner)
+	require.NoError(t, err)
+	require.Equal(t, inner, fsys)
+
+	_, err = prefixFS("../escape", inner)
+	require.ErrorIs(t, err, fs.ErrInvalid)
+}
+
+// copyPrefixFS is the previous eager prefixFS implementation, which copied
+// the whole of fsys into an fstest.MapFS. It is kept only as a benchmark
+// baseline.
+func copyPrefixFS(prefix string, fsys fs.FS) (fs.FS, error) {
+	m := make(fstest.MapFS)
+
+	prefix = filepath.FromSlash(prefix)
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		b, err := fs.ReadFile(fsys, filepath.ToSlash(path))
+		if err != nil {
+			return err
+		}
+		m[filepath.ToSlash(filepath.Join(prefix, path))] = &fstest.MapFile{Data: b}
+		return nil
+	})
+	return m, err
+}
+
+// BenchmarkPrefixFS measures preparing a tree of many kinds as a CUE loader
+// overlay, then reading one kind from it as LoadInstance's callers typically do.
+func BenchmarkPrefixFS(b *testing.B) {
+	tree := make(fstest.MapFS)
+	for i := 0; i < 500; i++ {
+		for _, f := range []string{"kind.cue", "lineage.cue", "README.md"} {
+			tree[fmt.Sprintf("kinds/kind%03d/%s", i, f)] = &fstest.MapFile{Data: make([]byte, 4<<10)}
+		}
+	}
+
+	for name, fn := range map[string]func(string, fs.FS) (fs.FS, error){
+		"eager": copyPrefixFS,
+		"lazy":  prefixFS,
+	} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				fsys, err := fn("grafana", tree)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := fs.ReadFile(fsys, "grafana/kinds/kind250/kind.cue"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
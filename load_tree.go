@@ -0,0 +1,195 @@
+package kindsys
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/parser"
+	"github.com/grafana/thema"
+)
+
+// LoadKindsConfig holds options for [LoadKinds].
+type LoadKindsConfig struct {
+	// Parallelism is the maximum number of kind definitions loaded and bound
+	// concurrently. Defaults to runtime.GOMAXPROCS(0) if less than one.
+	Parallelism int
+
+	// BindOptions are passed through when binding each kind's lineage.
+	BindOptions []thema.BindOption
+}
+
+// LoadKinds walks fsys starting from the root directory, and loads and binds
+// each kind definition it finds.
+//
+// Any directory containing .cue files that import the kindsys framework
+// (`import "github.com/grafana/kindsys"`) is treated as a kind definition
+// of any category, in the style of Grafana's kinds/ directory. Directories
+// named cue.mod, or beginning with "." or "_", are skipped.
+//
+// Each kind is loaded and bound in its own [cue.Context] and [thema.Runtime],
+// allowing definitions to be processed concurrently. Failures do not stop the
+// walk: all successfully bound kinds are returned, sorted by directory,
+// together with a [LoadErrors] containing one entry per failed directory.
+func LoadKinds(fsys fs.FS, root string, cfg LoadKindsConfig) ([]Kind, error) {
+	dirs, err := findKindDirs(fsys, root)
+	if err != nil {
+		return nil, err
+	}
+
+	par := cfg.Parallelism
+	if par < 1 {
+		par = runtime.GOMAXPROCS(0)
+	}
+
+	kinds := make([]Kind, len(dirs))
+	errs := make([]error, len(dirs))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, par)
+	for i, dir := range dirs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, dir string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			kinds[i], errs[i] = LoadKind(fsys, dir, cfg.BindOptions...)
+		}(i, dir)
+	}
+	wg.Wait()
+
+	var loaded []Kind
+	var lerrs LoadErrors
+	for i, dir := range dirs {
+		if errs[i] != nil {
+			lerrs = append(lerrs, &LoadError{Path: dir, Err: errs[i]})
+			continue
+		}
+		loaded = append(loaded, kinds[i])
+	}
+
+	if len(lerrs) > 0 {
+		return loaded, lerrs
+	}
+	return loaded, nil
+}
+
+// LoadKind loads the kind definition of any category in the directory dir of
+// fsys, then binds it in a new [cue.Context] and [thema.Runtime].
+func LoadKind(fsys fs.FS, dir string, opts ...thema.BindOption) (Kind, error) {
+	ctx := cuecontext.New()
+	def, err := LoadKindDef(fsys, dir, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rt := thema.NewRuntime(ctx)
+	switch props := def.Properties.(type) {
+	case CoreProperties:
+		return BindCore(rt, Def[CoreProperties]{V: def.V, Properties: props, Dir: def.Dir}, opts...)
+	case CustomProperties:
+		return BindCustom(rt, Def[CustomProperties]{V: def.V, Properties: props, Dir: def.Dir}, opts...)
+	case ComposableProperties:
+		return BindComposable(rt, Def[ComposableProperties]{V: def.V, Properties: props, Dir: def.Dir}, opts...)
+	default:
+		// unreachable so long as all the possibilities in KindProperties have switch branches
+		panic("unreachable")
+	}
+}
+
+// findKindDirs returns the sorted paths of all directories under root in fsys
+// containing .cue files that import the kindsys framework.
+func findKindDirs(fsys fs.FS, root string) ([]string, error) {
+	var dirs []string
+	err := fs.WalkDir(fsys, path.Clean(root), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if name := d.Name(); p != path.Clean(root) && (name == "cue.mod" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return fs.SkipDir
+		}
+
+		is, err := importsFramework(fsys, p)
+		if err != nil {
+			return err
+		}
+		if is {
+			dirs = append(dirs, p)
+		}
+		return nil
+	})
+
+	sort.Strings(dirs)
+	return dirs, err
+}
+
+// importsFramework indicates whether any .cue file directly within dir imports
+// the kindsys framework.
+func importsFramework(fsys fs.FS, dir string) (bool, error) {
+	ents, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return false, err
+	}
+
+	for _, ent := range ents {
+		if ent.IsDir() || !strings.HasSuffix(ent.Name(), ".cue") {
+			continue
+		}
+		fp := path.Join(dir, ent.Name())
+		b, err := fs.ReadFile(fsys, fp)
+		if err != nil {
+			return false, err
+		}
+		f, err := parser.ParseFile(fp, b, parser.ImportsOnly)
+		if err != nil {
+			// Let the loader report syntax errors, with positions
+			return true, nil //nolint:nilerr
+		}
+		for _, imp := range f.Imports {
+			if strings.Trim(imp.Path.Value, `"`) == frameworkImportPath {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// LoadError describes the failure to load or bind the kind definition in a
+// single directory.
+type LoadError struct {
+	// Path is the path of the kind definition's directory.
+	Path string
+	// Err is the error that occurred.
+	Err error
+}
+
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, strings.TrimSpace(errors.Details(e.Err, nil)))
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// LoadErrors aggregates the [LoadError]s from loading many kind definitions,
+// such as with [LoadKinds].
+type LoadErrors []*LoadError
+
+func (e LoadErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("failed to load %d kind(s):\n%s", len(e), strings.Join(msgs, "\n"))
+}
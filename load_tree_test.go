@@ -0,0 +1,59 @@
+package kindsys_test
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/kindsys"
+)
+
+func TestLoadKinds(t *testing.T) {
+	fsys := fstest.MapFS{
+		"kinds/broken/broken.cue": &fstest.MapFile{Data: []byte(`package broken
+
+import "github.com/grafana/kindsys"
+
+kindsys.Core
+name:        "broken"
+maturity:    "experimental"
+description: "Name is not PascalCase."
+lineage: schemas: [{
+	version: [0, 0]
+	schema: spec: title: string
+}]
+`)},
+		"kinds/_ignored/ignored.cue": &fstest.MapFile{Data: []byte(`package ignored
+
+import "github.com/grafana/kindsys"
+
+kindsys.Core
+`)},
+	}
+	for p, f := range testKindsFS {
+		fsys[p] = f
+	}
+
+	kinds, err := kindsys.LoadKinds(fsys, "kinds", kindsys.LoadKindsConfig{Parallelism: 2})
+	require.Error(t, err)
+
+	var names []string
+	for _, k := range kinds {
+		names = append(names, k.Name())
+	}
+	require.Equal(t, []string{"TestDataQuery", "TestCore", "TestCustom"}, names)
+	require.Implements(t, (*kindsys.Composable)(nil), kinds[0])
+	require.Implements(t, (*kindsys.Core)(nil), kinds[1])
+	require.Implements(t, (*kindsys.Custom)(nil), kinds[2])
+	require.Equal(t, "kinds/composable", kinds[0].(kindsys.Composable).Def().Dir)
+	require.Equal(t, "kinds/core", kinds[1].(kindsys.Core).Def().Dir)
+	require.Equal(t, "kinds/custom", kinds[2].(kindsys.Custom).Def().Dir)
+
+	var lerrs kindsys.LoadErrors
+	require.True(t, errors.As(err, &lerrs))
+	require.Len(t, lerrs, 1)
+	require.Equal(t, "kinds/broken", lerrs[0].Path)
+	require.Contains(t, lerrs[0].Error(), "broken.cue:")
+}
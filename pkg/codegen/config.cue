@@ -0,0 +1,57 @@
+package codegen
+
+// #Config is the schema for codegen configuration files. See Config in
+// config.go for details.
+#Config: {
+	// kinds are the directories, relative to the configuration file, under
+	// which kind definitions are loaded.
+	kinds: [string, ...string]
+
+	// header, if present, prefixes a comment header to each generated file
+	// that supports comments, marking it as generated.
+	header?: {
+		// generator is the path of the program that generates the files,
+		// e.g. "pkg/kinds/gen.go", or a command that runs it.
+		generator: string
+	}
+
+	// jennies are the generators to run over the loaded kinds.
+	jennies: [...#Jenny]
+}
+
+#Jenny: {
+	// jenny is the name of the jenny to run.
+	jenny: "GoTypesJenny" | "GoResourceJenny" | "TSTypesJenny" | "TSResourceJenny" | "TSRegistryJenny" | "JsonSchemaJenny" | "CRDJenny" | "OpenAPIJenny" | "DocsJenny"
+
+	// categories limits the jenny to kinds of the listed categories. All
+	// kinds are generated if absent.
+	categories?: [...("core" | "custom" | "composable")]
+
+	// output is the directory, relative to the configuration file, to which
+	// generated files are written.
+	output: string
+
+	// layout is the name of the jenny that selects which of each kind's
+	// schemas are generated, and where. It is not valid for CRDJenny or
+	// OpenAPIJenny, which generate from every schema.
+	layout?: "LatestMajorsOrXJenny" | "LatestJenny"
+
+	// expandReferences inlines referenced definitions in GoTypesJenny and
+	// GoResourceJenny output.
+	expandReferences?: bool
+
+	// importMappings maps CUE import paths to TypeScript import paths for
+	// TSTypesJenny, TSResourceJenny and TSRegistryJenny. A CUE import mapped
+	// to the empty string is omitted from TypeScript output.
+	importMappings?: [string]: string
+
+	// typesDir is the directory of the TSTypesJenny output exported by
+	// TSRegistryJenny, relative to output.
+	typesDir?: string
+
+	// group is the group of the kinds described by OpenAPIJenny.
+	group?: string
+
+	// format is the format of DocsJenny output, Markdown by default.
+	format?: "markdown" | "html"
+}
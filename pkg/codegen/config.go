@@ -0,0 +1,352 @@
+package codegen
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/encoding/json"
+	"cuelang.org/go/encoding/yaml"
+	"github.com/grafana/codejen"
+	"github.com/grafana/kindsys"
+)
+
+//go:embed config.cue
+var configSchema string
+
+// Config declares a codegen pipeline: the jennies to run over a tree of kinds,
+// and where their output is written. It is the Go form of a configuration file,
+// written in CUE, YAML or JSON, against the schema in config.cue. For example,
+// in YAML:
+//
+//	kinds: [kinds]
+//	header:
+//	  generator: pkg/kinds/gen.go
+//	jennies:
+//	  - jenny: GoTypesJenny
+//	    categories: [core, custom]
+//	    output: pkg/kinds
+//	  - jenny: TSTypesJenny
+//	    output: packages/types
+//	  - jenny: CRDJenny
+//	    output: crds
+//
+// Paths are slash-separated and relative to a base directory, usually that of
+// the configuration file.
+type Config struct {
+	// Kinds are the directories under which kind definitions are loaded, as
+	// by [kindsys.LoadKinds].
+	Kinds []string `json:"kinds"`
+
+	// Header, if non-nil, configures a comment header marking each generated
+	// file as generated, as by [SlashHeaderMapper].
+	Header *HeaderConfig `json:"header,omitempty"`
+
+	// Jennies are the jennies to run.
+	Jennies []JennyConfig `json:"jennies"`
+}
+
+// HeaderConfig configures the comment header of generated files.
+type HeaderConfig struct {
+	// Generator is the path of the program that generates the files.
+	Generator string `json:"generator"`
+}
+
+// JennyConfig configures a single jenny in a [Config].
+type JennyConfig struct {
+	// Jenny is the name of the jenny: one of GoTypesJenny, GoResourceJenny,
+	// TSTypesJenny, TSResourceJenny, TSRegistryJenny, JsonSchemaJenny,
+	// CRDJenny, OpenAPIJenny or DocsJenny.
+	//
+	// GoRegistryJenny cannot be configured, as it embeds the kind definitions
+	// read from the filesystem they were loaded from, so is only available
+	// to Go programs that construct it with that filesystem.
+	Jenny string `json:"jenny"`
+
+	// Categories limits the jenny to kinds of the listed categories: core,
+	// custom or composable. If empty, all kinds are generated.
+	Categories []string `json:"categories,omitempty"`
+
+	// Output is the directory to which the jenny's files are written.
+	Output string `json:"output"`
+
+	// Layout is the name of the jenny that selects the schemas passed to
+	// Jenny, and the directories beneath Output in which they are written:
+	// LatestMajorsOrXJenny, the default, or LatestJenny. Composable kinds are
+	// laid out by ComposableLatestMajorsOrXJenny in place of the former. It is
+	// not valid for CRDJenny or OpenAPIJenny, which generate from every schema
+	// in a kind.
+	// For TSRegistryJenny, it is the layout of the TSTypesJenny output whose
+	// types are exported.
+	Layout string `json:"layout,omitempty"`
+
+	// ExpandReferences is passed through to GoTypesJenny and GoResourceJenny.
+	ExpandReferences bool `json:"expandReferences,omitempty"`
+
+	// ImportMappings map CUE import paths to TypeScript import paths for
+	// TSTypesJenny, TSResourceJenny and TSRegistryJenny. A CUE import mapped
+	// to the empty string is omitted from TypeScript output, as the kindsys
+	// framework is by default.
+	ImportMappings map[string]string `json:"importMappings,omitempty"`
+
+	// TypesDir is passed through to TSRegistryJenny: the directory of the
+	// TSTypesJenny output, relative to Output.
+	TypesDir string `json:"typesDir,omitempty"`
+
+	// Group is passed through to OpenAPIJenny.
+	Group string `json:"group,omitempty"`
+
+	// Format is the format of DocsJenny output: markdown, the default, or
+	// html.
+	Format string `json:"format,omitempty"`
+}
+
+// LoadConfig reads and parses the configuration file at path, as by
+// [ParseConfig].
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(path, b)
+}
+
+// ParseConfig parses the configuration file contents src, validating it against
+// the config schema. The format of src, CUE, YAML or JSON, is determined by the
+// extension of filename.
+func ParseConfig(filename string, src []byte) (*Config, error) {
+	ctx := cuecontext.New()
+	schema := ctx.CompileString(configSchema, cue.Filename("config.cue")).LookupPath(cue.ParsePath("#Config"))
+	if schema.Err() != nil {
+		return nil, fmt.Errorf("failed compiling config schema: %w", schema.Err())
+	}
+
+	var v cue.Value
+	switch ext := filepath.Ext(filename); ext {
+	case ".cue":
+		v = ctx.CompileBytes(src, cue.Filename(filename))
+	case ".yaml", ".yml":
+		f, err := yaml.Extract(filename, src)
+		if err != nil {
+			return nil, err
+		}
+		v = ctx.BuildFile(f)
+	case ".json":
+		expr, err := json.Extract(filename, src)
+		if err != nil {
+			return nil, err
+		}
+		v = ctx.BuildExpr(expr)
+	default:
+		return nil, fmt.Errorf("%s: unsupported config format %q, expected .cue, .yaml, .yml or .json", filename, ext)
+	}
+	if v.Err() != nil {
+		return nil, v.Err()
+	}
+
+	v = schema.Unify(v)
+	if err := v.Validate(cue.Concrete(true)); err != nil {
+		return nil, fmt.Errorf("%s: invalid config: %w", filename, err)
+	}
+	cfg := new(Config)
+	if err := v.Decode(cfg); err != nil {
+		return nil, err
+	}
+	for i, jc := range cfg.Jennies {
+		if _, err := jc.jenny(); err != nil {
+			return nil, fmt.Errorf("%s: invalid config: jennies.%d: %w", filename, i, err)
+		}
+	}
+	return cfg, nil
+}
+
+// Generate runs the configured jennies over kinds, returning the generated
+// files with paths relative to the configuration's base directory.
+func (cfg *Config) Generate(kinds ...kindsys.Kind) (*codejen.FS, error) {
+	jfs := codejen.NewFS()
+	for _, jc := range cfg.Jennies {
+		j, err := jc.jenny()
+		if err != nil {
+			return nil, err
+		}
+
+		jl := codejen.JennyListWithNamer(func(k kindsys.Kind) string {
+			return k.Name()
+		})
+		jl.Append(j)
+		jl.AddPostprocessors(Prefixer(filepath.FromSlash(jc.Output)))
+		if cfg.Header != nil {
+			jl.AddPostprocessors(SlashHeaderMapper(cfg.Header.Generator))
+		}
+
+		gfs, err := jl.GenerateFS(jc.filter(kinds)...)
+		if err != nil {
+			return nil, err
+		}
+		if err = jfs.Merge(gfs); err != nil {
+			return nil, err
+		}
+	}
+	return jfs, nil
+}
+
+// Execute loads the configured kinds from beneath dir, the configuration's
+// base directory, and runs the configured jennies over them. The generated
+// files are written beneath dir or, if verify is true, compared against those
+// already there, returning an error describing each file that would have
+// changed.
+func (cfg *Config) Execute(dir string, verify bool) (*codejen.FS, error) {
+	var kinds []kindsys.Kind
+	for _, kdir := range cfg.Kinds {
+		dkinds, err := kindsys.LoadKinds(os.DirFS(dir), kdir, kindsys.LoadKindsConfig{})
+		if err != nil {
+			return nil, err
+		}
+		kinds = append(kinds, dkinds...)
+	}
+
+	jfs, err := cfg.Generate(kinds...)
+	if err != nil {
+		return nil, err
+	}
+	if verify {
+		return jfs, jfs.Verify(context.Background(), dir)
+	}
+	return jfs, jfs.Write(context.Background(), dir)
+}
+
+// jenny returns the configured jenny.
+func (jc JennyConfig) jenny() (codejen.Jenny[kindsys.Kind], error) {
+	if jc.ExpandReferences && !strings.HasPrefix(jc.Jenny, "Go") {
+		return nil, fmt.Errorf("expandReferences is not valid for %s", jc.Jenny)
+	}
+	if len(jc.ImportMappings) != 0 && !strings.HasPrefix(jc.Jenny, "TS") {
+		return nil, fmt.Errorf("importMappings is not valid for %s", jc.Jenny)
+	}
+	if jc.Format != "" && jc.Jenny != "DocsJenny" {
+		return nil, fmt.Errorf("format is not valid for %s", jc.Jenny)
+	}
+	if jc.TypesDir != "" && jc.Jenny != "TSRegistryJenny" {
+		return nil, fmt.Errorf("typesDir is not valid for %s", jc.Jenny)
+	}
+	if jc.Group != "" && jc.Jenny != "OpenAPIJenny" {
+		return nil, fmt.Errorf("group is not valid for %s", jc.Jenny)
+	}
+
+	var inner codejen.OneToOne[SchemaForGen]
+	switch jc.Jenny {
+	case "CRDJenny", "OpenAPIJenny":
+		if jc.Layout != "" {
+			return nil, fmt.Errorf("layout is not valid for %s", jc.Jenny)
+		}
+		if jc.Jenny == "OpenAPIJenny" {
+			return OpenAPIJenny{Group: jc.Group}, nil
+		}
+		return CRDJenny{}, nil
+	case "TSRegistryJenny":
+		types, err := jc.layout(TSTypesJenny{ImportMapper: jc.importMapper()})
+		if err != nil {
+			return nil, err
+		}
+		return TSRegistryJenny{Types: types, TypesDir: jc.TypesDir}, nil
+	case "GoTypesJenny":
+		inner = GoTypesJenny{ExpandReferences: jc.ExpandReferences}
+	case "GoResourceJenny":
+		inner = GoResourceJenny{ExpandReferences: jc.ExpandReferences}
+	case "TSTypesJenny":
+		inner = TSTypesJenny{ImportMapper: jc.importMapper()}
+	case "TSResourceJenny":
+		inner = TSResourceJenny{ImportMapper: jc.importMapper()}
+	case "JsonSchemaJenny":
+		inner = JsonSchemaJenny{}
+	case "DocsJenny":
+		inner = DocsJenny{HTML: jc.Format == "html"}
+	default:
+		return nil, fmt.Errorf("unknown jenny %q", jc.Jenny)
+	}
+	return jc.layout(inner)
+}
+
+// layout returns the configured layout jenny wrapping inner.
+func (jc JennyConfig) layout(inner codejen.OneToOne[SchemaForGen]) (codejen.Jenny[kindsys.Kind], error) {
+	switch jc.Layout {
+	case "", "LatestMajorsOrXJenny":
+		return latestMajorsOrX{
+			kinds:       LatestMajorsOrXJenny("", false, inner),
+			composables: ComposableLatestMajorsOrXJenny("", inner),
+		}, nil
+	case "LatestJenny":
+		return LatestJenny("", inner), nil
+	default:
+		return nil, fmt.Errorf("unknown layout %q", jc.Layout)
+	}
+}
+
+// latestMajorsOrX is the LatestMajorsOrXJenny layout, which lays out
+// composable kinds with ComposableLatestMajorsOrXJenny.
+type latestMajorsOrX struct {
+	kinds       codejen.OneToMany[kindsys.Kind]
+	composables codejen.OneToMany[kindsys.Composable]
+}
+
+func (j latestMajorsOrX) JennyName() string {
+	return j.kinds.JennyName()
+}
+
+func (j latestMajorsOrX) Generate(k kindsys.Kind) (codejen.Files, error) {
+	if ck, is := k.(kindsys.Composable); is {
+		return j.composables.Generate(ck)
+	}
+	return j.kinds.Generate(k)
+}
+
+// importMapper returns a cuetsy.ImportMapper for the configured import
+// mappings, to which the kindsys framework is added.
+func (jc JennyConfig) importMapper() func(string) (string, error) {
+	return func(path string) (string, error) {
+		if to, has := jc.ImportMappings[path]; has {
+			return to, nil
+		}
+		if path == "github.com/grafana/kindsys" {
+			// the framework has no TypeScript counterpart
+			return "", nil
+		}
+		return "", fmt.Errorf("no TypeScript import mapping for CUE import %q", path)
+	}
+}
+
+// filter returns the kinds in the configured categories.
+func (jc JennyConfig) filter(kinds []kindsys.Kind) []kindsys.Kind {
+	if len(jc.Categories) == 0 {
+		return kinds
+	}
+	var fkinds []kindsys.Kind
+	for _, k := range kinds {
+		for _, cat := range jc.Categories {
+			if kindCategory(k) == cat {
+				fkinds = append(fkinds, k)
+				break
+			}
+		}
+	}
+	return fkinds
+}
+
+// kindCategory returns the name of the kind's category, as written in a
+// config: core, custom or composable.
+func kindCategory(k kindsys.Kind) string {
+	switch k.(type) {
+	case kindsys.Core:
+		return "core"
+	case kindsys.Custom:
+		return "custom"
+	case kindsys.Composable:
+		return "composable"
+	}
+	return ""
+}
@@ -0,0 +1,157 @@
+package codegen
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/kindsys"
+)
+
+func TestParseConfig(t *testing.T) {
+	want := &Config{
+		Kinds: []string{"kinds"},
+		Jennies: []JennyConfig{
+			{Jenny: "GoTypesJenny", Output: "pkg/kinds", ExpandReferences: true},
+			{Jenny: "TSTypesJenny", Output: "ts", Layout: "LatestJenny"},
+		},
+	}
+
+	tests := map[string]struct {
+		filename, src string
+		err           string
+	}{
+		"cue": {
+			filename: "gen.cue",
+			src: `kinds: ["kinds"]
+jennies: [
+	{jenny: "GoTypesJenny", output: "pkg/kinds", expandReferences: true},
+	{jenny: "TSTypesJenny", output: "ts", layout: "LatestJenny"},
+]`,
+		},
+		"yaml": {
+			filename: "gen.yaml",
+			src: `kinds: [kinds]
+jennies:
+  - {jenny: GoTypesJenny, output: pkg/kinds, expandReferences: true}
+  - {jenny: TSTypesJenny, output: ts, layout: LatestJenny}
+`,
+		},
+		"json": {
+			filename: "gen.json",
+			src: `{"kinds": ["kinds"], "jennies": [
+	{"jenny": "GoTypesJenny", "output": "pkg/kinds", "expandReferences": true},
+	{"jenny": "TSTypesJenny", "output": "ts", "layout": "LatestJenny"}
+]}`,
+		},
+		"unknown format": {
+			filename: "gen.toml",
+			err:      `unsupported config format ".toml"`,
+		},
+		"unknown jenny": {
+			filename: "gen.yaml",
+			src:      "kinds: [kinds]\njennies: [{jenny: FooJenny, output: foo}]",
+			err:      "invalid config",
+		},
+		"unknown field": {
+			filename: "gen.yaml",
+			src:      "kinds: [kinds]\njennies: []\nfoo: bar",
+			err:      "invalid config",
+		},
+		"no output": {
+			filename: "gen.yaml",
+			src:      "kinds: [kinds]\njennies: [{jenny: GoTypesJenny}]",
+			err:      "invalid config",
+		},
+		"crd layout": {
+			filename: "gen.yaml",
+			src:      "kinds: [kinds]\njennies: [{jenny: CRDJenny, output: crds, layout: LatestJenny}]",
+			err:      "jennies.0: layout is not valid for CRDJenny",
+		},
+		"go import mappings": {
+			filename: "gen.yaml",
+			src:      "kinds: [kinds]\njennies: [{jenny: GoTypesJenny, output: go, importMappings: {foo: bar}}]",
+			err:      "jennies.0: importMappings is not valid for GoTypesJenny",
+		},
+		"go group": {
+			filename: "gen.yaml",
+			src:      "kinds: [kinds]\njennies: [{jenny: GoTypesJenny, output: go, group: foo}]",
+			err:      "jennies.0: group is not valid for GoTypesJenny",
+		},
+		"openapi layout": {
+			filename: "gen.yaml",
+			src:      "kinds: [kinds]\njennies: [{jenny: OpenAPIJenny, output: openapi, layout: LatestJenny}]",
+			err:      "jennies.0: layout is not valid for OpenAPIJenny",
+		},
+		"ts types dir": {
+			filename: "gen.yaml",
+			src:      "kinds: [kinds]\njennies: [{jenny: TSTypesJenny, output: ts, typesDir: types}]",
+			err:      "jennies.0: typesDir is not valid for TSTypesJenny",
+		},
+		"go registry": {
+			filename: "gen.yaml",
+			src:      "kinds: [kinds]\njennies: [{jenny: GoRegistryJenny, output: registry}]",
+			err:      "invalid config",
+		},
+		"go format": {
+			filename: "gen.yaml",
+			src:      "kinds: [kinds]\njennies: [{jenny: GoTypesJenny, output: go, format: html}]",
+			err:      "jennies.0: format is not valid for GoTypesJenny",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := ParseConfig(tt.filename, []byte(tt.src))
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, want, cfg)
+		})
+	}
+}
+
+func TestConfig_Execute(t *testing.T) {
+	cfg, err := LoadConfig("testdata/codegen/config.yaml")
+	require.NoError(t, err)
+
+	updateOutputFiles := os.Getenv("KINDSYS_GEN_UPDATE_GOLDEN_FILES") != ""
+	jfs, err := cfg.Execute("testdata/codegen", !updateOutputFiles)
+	require.NoError(t, err)
+
+	var paths []string
+	for _, f := range jfs.AsFiles() {
+		paths = append(paths, f.RelativePath)
+	}
+	require.Equal(t, []string{
+		"output/config/docs/folder/x/folder_docs.html",
+		"output/config/go/folder/x/folder_types_gen.go",
+		"output/config/openapi/folder.core.grafana.com.openapi.json",
+		"output/config/ts/folder/folder_types.gen.ts",
+		"output/config/ts/index.gen.ts",
+	}, paths)
+}
+
+func TestConfig_GenerateComposable(t *testing.T) {
+	cfg, err := ParseConfig("gen.yaml", []byte("kinds: [composables]\njennies: [{jenny: GoTypesJenny, output: go}]"))
+	require.NoError(t, err)
+	kinds, err := kindsys.LoadKinds(os.DirFS("testdata/codegen"), "composables", kindsys.LoadKindsConfig{})
+	require.NoError(t, err)
+
+	jfs, err := cfg.Generate(kinds...)
+	require.NoError(t, err)
+	var paths []string
+	for _, f := range jfs.AsFiles() {
+		paths = append(paths, f.RelativePath)
+	}
+	// composable kinds are laid out by plugin and schema interface
+	require.Equal(t, []string{
+		"go/testdata/dataquery/v0/testdatadataquery_types_gen.go",
+		"go/testdata/dataquery/v1/testdatadataquery_types_gen.go",
+		"go/text/panelcfg/x/textpanelcfg_types_gen.go",
+		"go/timeseries/panelcfg/x/timeseriespanelcfg_types_gen.go",
+	}, paths)
+}
@@ -46,7 +46,7 @@ func SlashHeaderMapper(maingen string) codejen.FileMapper {
 		var leader string
 		// Never inject on certain filetypes, it's never valid
 		switch filepath.Ext(f.RelativePath) {
-		case ".json", ".md":
+		case ".json", ".md", ".html":
 			return f, nil
 		case ".yml", ".yaml":
 			leader = "#"
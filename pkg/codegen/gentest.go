@@ -1,18 +1,20 @@
 package codegen
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
-	"testing/fstest"
 
 	"cuelang.org/go/cue/cuecontext"
 	"github.com/grafana/codejen"
 	"github.com/grafana/kindsys"
 	"github.com/grafana/thema"
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,6 +22,10 @@ type GenTestConfig struct {
 	OutputDir string
 }
 
+// GenTest runs jennies over kinds loaded from testdata, and compares the
+// generated files against golden files beneath OutputDir. If the
+// KINDSYS_GEN_UPDATE_GOLDEN_FILES environment variable is set, the golden
+// files are written instead.
 type GenTest struct {
 	config GenTestConfig
 
@@ -35,39 +41,110 @@ func NewGenTest(t *testing.T, config GenTestConfig) GenTest {
 	}
 }
 
+// RunManyToOneFromModule runs the jenny over the single kind defined in the
+// CUE module at modulePath. Use [GenTest.RunManyToOneFromDir] to run it over
+// several kinds.
 func (genTest GenTest) RunManyToOneFromModule(modulePath string, jenny ManyToOne) {
+	genTest.t.Helper()
 	genTest.Run(func(t *testing.T) codejen.Files {
 		req := require.New(t)
 
-		kind, err := genTest.ModuleToCoreKind(modulePath)
+		kind, err := genTest.ModuleToKind(modulePath)
 		req.NoError(err)
 
 		resultFile, err := jenny.Generate(kind)
 		req.NoError(err)
 
-		return codejen.Files{*resultFile}
+		return existingFiles(resultFile)
+	})
+}
+
+// RunManyToOneFromDir runs the jenny over every kind defined beneath dir.
+func (genTest GenTest) RunManyToOneFromDir(dir string, jenny ManyToOne) {
+	genTest.t.Helper()
+	genTest.Run(func(t *testing.T) codejen.Files {
+		req := require.New(t)
+
+		kinds, err := genTest.DirToKinds(dir)
+		req.NoError(err)
+
+		resultFile, err := jenny.Generate(kinds...)
+		req.NoError(err)
+
+		return existingFiles(resultFile)
+	})
+}
+
+// RunManyToManyFromModule runs the jenny over the single kind defined in the
+// CUE module at modulePath. Use [GenTest.RunManyToManyFromDir] to run it over
+// several kinds.
+func (genTest GenTest) RunManyToManyFromModule(modulePath string, jenny ManyToMany) {
+	genTest.t.Helper()
+	genTest.Run(func(t *testing.T) codejen.Files {
+		req := require.New(t)
+
+		kind, err := genTest.ModuleToKind(modulePath)
+		req.NoError(err)
+
+		resultFiles, err := jenny.Generate(kind)
+		req.NoError(err)
+
+		return resultFiles
+	})
+}
+
+// RunManyToManyFromDir runs the jenny over every kind defined beneath dir.
+func (genTest GenTest) RunManyToManyFromDir(dir string, jenny ManyToMany) {
+	genTest.t.Helper()
+	genTest.Run(func(t *testing.T) codejen.Files {
+		req := require.New(t)
+
+		kinds, err := genTest.DirToKinds(dir)
+		req.NoError(err)
+
+		resultFiles, err := jenny.Generate(kinds...)
+		req.NoError(err)
+
+		return resultFiles
 	})
 }
 
 func (genTest GenTest) RunOneToOneFromModule(modulePath string, jenny OneToOne) {
+	genTest.t.Helper()
 	genTest.Run(func(t *testing.T) codejen.Files {
 		req := require.New(t)
 
-		kind, err := genTest.ModuleToCoreKind(modulePath)
+		kind, err := genTest.ModuleToKind(modulePath)
 		req.NoError(err)
 
 		resultFile, err := jenny.Generate(kind)
 		req.NoError(err)
 
-		return codejen.Files{*resultFile}
+		return existingFiles(resultFile)
 	})
 }
 
 func (genTest GenTest) RunOneToManyFromModule(modulePath string, jenny OneToMany) {
+	genTest.t.Helper()
+	genTest.Run(func(t *testing.T) codejen.Files {
+		req := require.New(t)
+
+		kind, err := genTest.ModuleToKind(modulePath)
+		req.NoError(err)
+
+		resultFiles, err := jenny.Generate(kind)
+		req.NoError(err)
+
+		return resultFiles
+	})
+}
+
+func (genTest GenTest) RunComposableFromModule(modulePath string, jenny codejen.OneToMany[kindsys.Composable]) {
+	genTest.t.Helper()
 	genTest.Run(func(t *testing.T) codejen.Files {
 		req := require.New(t)
 
-		kind, err := genTest.ModuleToCoreKind(modulePath)
+		kind, err := genTest.ModuleToComposableKind(modulePath)
 		req.NoError(err)
 
 		resultFiles, err := jenny.Generate(kind)
@@ -77,46 +154,133 @@ func (genTest GenTest) RunOneToManyFromModule(modulePath string, jenny OneToMany
 	})
 }
 
+// Run compares the files returned by inner against the golden files, failing
+// with a unified diff of each file that differs. It also fails if inner
+// returns no files, or if a golden file beneath OutputDir was not generated.
 func (genTest GenTest) Run(inner func(t *testing.T) codejen.Files) {
+	genTest.t.Helper()
 	req := require.New(genTest.t)
 
 	updateOutputFiles := os.Getenv("KINDSYS_GEN_UPDATE_GOLDEN_FILES") != ""
 	rootCodeJenFS := codejen.NewFS()
 
 	generatedFiles := inner(genTest.t)
+	if len(generatedFiles) == 0 {
+		genTest.t.Errorf("no files were generated for %s", genTest.config.OutputDir)
+		return
+	}
+	generated := make(map[string]bool)
 	for _, file := range generatedFiles {
 		req.NoError(rootCodeJenFS.Add(file))
+		generated[filepath.Join(genTest.config.OutputDir, file.RelativePath)] = true
 	}
 
+	goldens, err := goldenFiles(genTest.config.OutputDir)
+	req.NoError(err)
+
 	if updateOutputFiles {
+		for _, path := range goldens {
+			if !generated[path] {
+				req.NoError(os.Remove(path))
+			}
+		}
 		req.NoError(rootCodeJenFS.Write(context.Background(), genTest.config.OutputDir))
-	} else {
-		req.NoError(rootCodeJenFS.Verify(context.Background(), genTest.config.OutputDir))
+		return
+	}
+
+	for _, path := range goldens {
+		if !generated[path] {
+			genTest.t.Errorf("golden file %s was not generated; set KINDSYS_GEN_UPDATE_GOLDEN_FILES=1 to remove it", path)
+		}
+	}
+
+	for _, file := range rootCodeJenFS.AsFiles() {
+		path := filepath.Join(genTest.config.OutputDir, file.RelativePath)
+		golden, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			genTest.t.Errorf("%s was generated, but has no golden file; set KINDSYS_GEN_UPDATE_GOLDEN_FILES=1 to write it", path)
+			continue
+		}
+		req.NoError(err)
+
+		if !bytes.Equal(golden, file.Data) {
+			diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(string(golden)),
+				B:        difflib.SplitLines(string(file.Data)),
+				FromFile: path,
+				ToFile:   "generated",
+				Context:  3,
+			})
+			req.NoError(err)
+			genTest.t.Errorf("generated file differs from golden file; set KINDSYS_GEN_UPDATE_GOLDEN_FILES=1 to update it:\n%s", diff)
+		}
 	}
 }
 
-func (genTest GenTest) ModuleToCoreKind(modulePath string) (kindsys.Core, error) {
-	overlayFS, err := dirToPrefixedFS(modulePath, "")
+// goldenFiles returns the paths of the files beneath dir, which need not
+// exist.
+func goldenFiles(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == dir {
+			return fs.SkipDir
+		}
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	return paths, err
+}
+
+// ModuleToKind loads the kind, of any category, defined in the CUE module at
+// modulePath, and binds it.
+func (genTest GenTest) ModuleToKind(modulePath string) (kindsys.Kind, error) {
+	kindDefinition, err := kindsys.LoadKindDef(os.DirFS(modulePath), ".", genTest.themaRuntime.Context())
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("could not load kind definition: %w", err)
 	}
 
-	cueInstance, err := kindsys.BuildInstance(genTest.themaRuntime.Context(), ".", "kind", overlayFS)
+	var boundKind kindsys.Kind
+	switch props := kindDefinition.Properties.(type) {
+	case kindsys.CoreProperties:
+		boundKind, err = kindsys.BindCore(genTest.themaRuntime, kindsys.Def[kindsys.CoreProperties]{V: kindDefinition.V, Properties: props, Dir: kindDefinition.Dir})
+	case kindsys.CustomProperties:
+		boundKind, err = kindsys.BindCustom(genTest.themaRuntime, kindsys.Def[kindsys.CustomProperties]{V: kindDefinition.V, Properties: props, Dir: kindDefinition.Dir})
+	case kindsys.ComposableProperties:
+		boundKind, err = kindsys.BindComposable(genTest.themaRuntime, kindsys.Def[kindsys.ComposableProperties]{V: kindDefinition.V, Properties: props, Dir: kindDefinition.Dir})
+	}
 	if err != nil {
-		return nil, fmt.Errorf("could not load kindsys instance: %w", err)
+		return nil, fmt.Errorf("could not bind kind definition to kind: %w", err)
 	}
 
-	props, err := kindsys.ToKindProps[kindsys.CoreProperties](cueInstance)
+	return boundKind, nil
+}
+
+func (genTest GenTest) ModuleToCoreKind(modulePath string) (kindsys.Core, error) {
+	kindDefinition, err := kindsys.LoadCoreKindDef(os.DirFS(modulePath), ".", genTest.themaRuntime.Context())
 	if err != nil {
-		return nil, fmt.Errorf("could not convert cue value to kindsys props: %w", err)
+		return nil, fmt.Errorf("could not load kind definition: %w", err)
 	}
 
-	kindDefinition := kindsys.Def[kindsys.CoreProperties]{
-		V:          cueInstance,
-		Properties: props,
+	boundKind, err := kindsys.BindCore(genTest.themaRuntime, kindDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("could not bind kind definition to kind: %w", err)
 	}
 
-	boundKind, err := kindsys.BindCore(genTest.themaRuntime, kindDefinition)
+	return boundKind, nil
+}
+
+func (genTest GenTest) ModuleToCustomKind(modulePath string) (kindsys.Custom, error) {
+	kindDefinition, err := kindsys.LoadCustomKindDef(os.DirFS(modulePath), ".", genTest.themaRuntime.Context())
+	if err != nil {
+		return nil, fmt.Errorf("could not load kind definition: %w", err)
+	}
+
+	boundKind, err := kindsys.BindCustom(genTest.themaRuntime, kindDefinition)
 	if err != nil {
 		return nil, fmt.Errorf("could not bind kind definition to kind: %w", err)
 	}
@@ -124,25 +288,39 @@ func (genTest GenTest) ModuleToCoreKind(modulePath string) (kindsys.Core, error)
 	return boundKind, nil
 }
 
-func dirToPrefixedFS(directory string, prefix string) (fs.FS, error) {
-	dirHandle, err := os.ReadDir(directory)
+func (genTest GenTest) ModuleToComposableKind(modulePath string) (kindsys.Composable, error) {
+	kindDefinition, err := kindsys.LoadComposableKindDef(os.DirFS(modulePath), ".", genTest.themaRuntime.Context())
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("could not load kind definition: %w", err)
 	}
 
-	commonFS := fstest.MapFS{}
-	for _, file := range dirHandle {
-		if file.IsDir() {
-			continue
-		}
+	boundKind, err := kindsys.BindComposable(genTest.themaRuntime, kindDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("could not bind kind definition to kind: %w", err)
+	}
 
-		content, err := os.ReadFile(filepath.Join(directory, file.Name()))
-		if err != nil {
-			return nil, err
-		}
+	return boundKind, nil
+}
 
-		commonFS[filepath.Join(prefix, file.Name())] = &fstest.MapFile{Data: content}
+// DirToKinds loads and binds every kind, of any category, defined beneath dir,
+// as by [kindsys.LoadKinds]. The kinds are ordered by the paths of their
+// directories.
+func (genTest GenTest) DirToKinds(dir string) ([]kindsys.Kind, error) {
+	kinds, err := kindsys.LoadKinds(os.DirFS(dir), ".", kindsys.LoadKindsConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("could not load kinds: %w", err)
+	}
+	if len(kinds) == 0 {
+		return nil, fmt.Errorf("no kind definitions found beneath %s", dir)
 	}
+	return kinds, nil
+}
 
-	return commonFS, nil
+// existingFiles returns the file as Files, or no Files if it is nil or
+// empty, as a jenny returns when it has nothing to generate.
+func existingFiles(f *codejen.File) codejen.Files {
+	if f == nil || !f.Exists() {
+		return nil
+	}
+	return codejen.Files{*f}
 }
@@ -0,0 +1,38 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/grafana/codejen"
+	"github.com/grafana/kindsys"
+)
+
+// categoryJenny is a ManyToMany that lists the kinds of each category.
+type categoryJenny struct{}
+
+func (j categoryJenny) JennyName() string {
+	return "categoryJenny"
+}
+
+func (j categoryJenny) Generate(kinds ...kindsys.Kind) (codejen.Files, error) {
+	names := make(map[string][]string)
+	for _, k := range kinds {
+		names[kindCategory(k)] = append(names[kindCategory(k)], k.Name())
+	}
+	var files codejen.Files
+	for _, cat := range []string{"core", "custom", "composable"} {
+		data := fmt.Sprintf("%s\n", strings.Join(names[cat], "\n"))
+		files = append(files, *codejen.NewFile(cat+".txt", []byte(data), j))
+	}
+	return files, nil
+}
+
+func TestGenTest_RunManyToManyFromDir(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/all_categoryJenny",
+	})
+
+	test.RunManyToManyFromDir("testdata/codegen", categoryJenny{})
+}
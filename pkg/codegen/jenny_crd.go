@@ -0,0 +1,243 @@
+package codegen
+
+import (
+	"fmt"
+	"strconv"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/cuecontext"
+	copenapi "cuelang.org/go/encoding/openapi"
+	"cuelang.org/go/encoding/yaml"
+	"github.com/grafana/codejen"
+	"github.com/grafana/kindsys"
+	"github.com/grafana/thema"
+	"github.com/grafana/thema/encoding/openapi"
+)
+
+// CRDJenny is a [OneToOne] that produces a Kubernetes CustomResourceDefinition,
+// in YAML, for a [kindsys.Core] kind or a [kindsys.Custom] kind with the crd
+// trait.
+//
+// Every schema in the kind's lineage is served as a separate CRD version, named
+// by [kindsys.APIVersion] such that 0.1 becomes "v0-1". The schema at the
+// kind's current version is the storage version. No file is produced for other
+// kinds.
+type CRDJenny struct{}
+
+var _ codejen.OneToOne[kindsys.Kind] = &CRDJenny{}
+
+func (j CRDJenny) JennyName() string {
+	return "CRDJenny"
+}
+
+func (j CRDJenny) Generate(k kindsys.Kind) (*codejen.File, error) {
+	rk, ok := k.(kindsys.ResourceKind)
+	if !ok {
+		return nil, nil
+	}
+	scope, ok := crdScope(k)
+	if !ok {
+		return nil, nil
+	}
+
+	comm := k.Props().Common()
+	var versions []ast.Expr
+	for sch := k.Lineage().First(); sch != nil; sch = sch.Successor() {
+		oapi, err := crdSchema(sch, comm.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed generating openapi for schema %s: %w", sch.Version(), err)
+		}
+		versions = append(versions, ast.NewStruct(
+			"name", ast.NewString(kindsys.APIVersion(sch.Version())),
+			"served", ast.NewBool(true),
+			"storage", ast.NewBool(sch.Version() == k.CurrentVersion()),
+			"schema", ast.NewStruct("openAPIV3Schema", oapi),
+			"subresources", ast.NewStruct("status", ast.NewStruct()),
+		))
+	}
+
+	crd := ast.NewStruct(
+		"apiVersion", ast.NewString("apiextensions.k8s.io/v1"),
+		"kind", ast.NewString("CustomResourceDefinition"),
+		"metadata", ast.NewStruct(
+			"name", ast.NewString(comm.PluralMachineName+"."+rk.Group()),
+		),
+		"spec", ast.NewStruct(
+			"group", ast.NewString(rk.Group()),
+			"scope", ast.NewString(scope),
+			"names", ast.NewStruct(
+				"kind", ast.NewString(comm.Name),
+				"listKind", ast.NewString(comm.Name+"List"),
+				"plural", ast.NewString(comm.PluralMachineName),
+				"singular", ast.NewString(comm.MachineName),
+			),
+			"versions", ast.NewList(versions...),
+		),
+	)
+
+	b, err := yaml.Encode(cuecontext.New().BuildExpr(crd))
+	if err != nil {
+		return nil, err
+	}
+	return codejen.NewFile(comm.MachineName+".crd.yaml", b, j), nil
+}
+
+// crdScope returns the CRD scope of the kind, if the kind is a CRD.
+func crdScope(k kindsys.Kind) (string, bool) {
+	var scope string
+	switch props := k.Props().(type) {
+	case kindsys.CoreProperties:
+		scope = props.CRD.Scope
+	case kindsys.CustomProperties:
+		if !props.IsCRD {
+			return "", false
+		}
+		scope = props.CRD.Scope
+	default:
+		return "", false
+	}
+	if scope == "" {
+		scope = "Namespaced"
+	}
+	return scope, true
+}
+
+// crdSchema returns the schema as a Kubernetes structural OpenAPI schema.
+func crdSchema(sch thema.Schema, name string) (*ast.StructLit, error) {
+	f, err := openapi.GenerateSchema(sch, &openapi.Config{
+		Config: &copenapi.Config{
+			// CRD schemas may not contain references
+			ExpandReferences: true,
+		},
+		RootName: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var root *ast.StructLit
+	if len(f.Decls) == 1 {
+		if doc, is := f.Decls[0].(*ast.StructLit); is {
+			comps, _ := lookupField(doc, "components").(*ast.StructLit)
+			schemas, _ := lookupField(comps, "schemas").(*ast.StructLit)
+			root, _ = lookupField(schemas, name).(*ast.StructLit)
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("no schema component named %s in generated openapi", name)
+	}
+
+	// metadata is Kubernetes' ObjectMeta, and status is managed through the
+	// status subresource, so neither is required of objects. Kubernetes also
+	// forbids any schema for metadata beyond name and generateName.
+	if props, is := lookupField(root, "properties").(*ast.StructLit); is {
+		deleteField(props, "metadata")
+	}
+	if req, is := lookupField(root, "required").(*ast.ListLit); is {
+		var elts []ast.Expr
+		for _, elt := range req.Elts {
+			if s := stringLit(elt); s != "metadata" && s != "status" {
+				elts = append(elts, elt)
+			}
+		}
+		req.Elts = elts
+	}
+	structural(root)
+	return root, nil
+}
+
+// structural rewrites constructs in the OpenAPI schema s that are not allowed
+// in the structural schemas Kubernetes requires of CRDs:
+//
+//   - An empty schema, or an additionalProperties of one, which accepts any
+//     value, becomes x-kubernetes-preserve-unknown-fields.
+//   - additionalProperties beside properties, which are mutually exclusive,
+//     becomes x-kubernetes-preserve-unknown-fields.
+//
+// It is best-effort. Other non-structural constructs, such as a type specified
+// within the branches of a oneOf, are left in place.
+func structural(s *ast.StructLit) {
+	if len(s.Elts) == 0 {
+		s.Elts = []ast.Decl{preserveUnknown()}
+		return
+	}
+
+	props, hasProps := lookupField(s, "properties").(*ast.StructLit)
+	if hasProps {
+		for _, elt := range props.Elts {
+			if f, is := elt.(*ast.Field); is {
+				if sub, is := f.Value.(*ast.StructLit); is {
+					structural(sub)
+				}
+			}
+		}
+	}
+
+	if addl, is := lookupField(s, "additionalProperties").(*ast.StructLit); is {
+		if hasProps || len(addl.Elts) == 0 {
+			deleteField(s, "additionalProperties")
+			s.Elts = append(s.Elts, preserveUnknown())
+		} else {
+			structural(addl)
+		}
+	}
+
+	if items, is := lookupField(s, "items").(*ast.StructLit); is {
+		structural(items)
+	}
+	for _, comb := range []string{"allOf", "anyOf", "oneOf"} {
+		if l, is := lookupField(s, comb).(*ast.ListLit); is {
+			for _, elt := range l.Elts {
+				if sub, is := elt.(*ast.StructLit); is && len(sub.Elts) > 0 {
+					structural(sub)
+				}
+			}
+		}
+	}
+}
+
+func preserveUnknown() *ast.Field {
+	return &ast.Field{
+		Label: ast.NewString("x-kubernetes-preserve-unknown-fields"),
+		Value: ast.NewBool(true),
+	}
+}
+
+// lookupField returns the value of the named field in the struct literal s, or
+// nil if there is none.
+func lookupField(s *ast.StructLit, name string) ast.Expr {
+	if s == nil {
+		return nil
+	}
+	for _, elt := range s.Elts {
+		if f, is := elt.(*ast.Field); is {
+			if l, _, err := ast.LabelName(f.Label); err == nil && l == name {
+				return f.Value
+			}
+		}
+	}
+	return nil
+}
+
+// deleteField removes the named field from the struct literal s.
+func deleteField(s *ast.StructLit, name string) {
+	elts := s.Elts[:0]
+	for _, elt := range s.Elts {
+		if f, is := elt.(*ast.Field); is {
+			if l, _, err := ast.LabelName(f.Label); err == nil && l == name {
+				continue
+			}
+		}
+		elts = append(elts, elt)
+	}
+	s.Elts = elts
+}
+
+func stringLit(x ast.Expr) string {
+	if lit, is := x.(*ast.BasicLit); is {
+		if s, err := strconv.Unquote(lit.Value); err == nil {
+			return s
+		}
+	}
+	return ""
+}
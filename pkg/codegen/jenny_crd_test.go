@@ -0,0 +1,27 @@
+package codegen
+
+import (
+	"testing"
+)
+
+func TestCRDJenny_NoParams(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/folder_CRDJenny_NoParams",
+	})
+
+	test.RunOneToOneFromModule(
+		"testdata/codegen/schemas/folder",
+		CRDJenny{},
+	)
+}
+
+func TestCRDJenny_Custom(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/thing_CRDJenny_NoParams",
+	})
+
+	test.RunOneToOneFromModule(
+		"testdata/codegen/customs/thing",
+		CRDJenny{},
+	)
+}
@@ -0,0 +1,163 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"github.com/grafana/codejen"
+	"github.com/grafana/kindsys"
+	"github.com/grafana/kindsys/pkg/compat"
+)
+
+// DocsJenny is a [OneToOne] that produces reference documentation, in Markdown
+// or HTML, for the provided [thema.Schema] of a kind.
+//
+// The document describes the kind, then lists the fields of each schema in the
+// same major version as the provided schema, up to and including it, newest
+// first. It ends with a changelog of the changes, as classified by
+// [compat.DiffSchemas], between each pair of consecutive schemas in the lineage
+// up to the provided schema.
+//
+// The kind's description, maturity, group and scope are only known when
+// DocsJenny is run through [LatestJenny] or [LatestMajorsOrXJenny], which
+// place the documentation alongside code generated by other jennies.
+type DocsJenny struct {
+	// HTML renders the documentation as HTML, rather than Markdown.
+	HTML bool
+
+	kind kindsys.Kind
+}
+
+var _ codejen.OneToOne[SchemaForGen] = &DocsJenny{}
+
+func (j DocsJenny) JennyName() string {
+	return "DocsJenny"
+}
+
+func (j DocsJenny) forKind(k kindsys.Kind) codejen.OneToOne[SchemaForGen] {
+	j.kind = k
+	return j
+}
+
+func (j DocsJenny) Generate(sfg SchemaForGen) (*codejen.File, error) {
+	vars := tvars_docs{
+		Name:    sfg.Name,
+		Version: sfg.Schema.Version().String(),
+	}
+	if j.kind != nil {
+		comm := j.kind.Props().Common()
+		vars.Description = comm.Description
+		vars.Maturity = string(comm.Maturity)
+		vars.Category = kindCategory(j.kind)
+		vars.Current = j.kind.CurrentVersion().String()
+		if rk, is := j.kind.(kindsys.ResourceKind); is {
+			vars.Group = rk.Group()
+		}
+		if scope, is := crdScope(j.kind); is {
+			vars.Scope = scope
+		}
+	}
+
+	major := sfg.Schema.Version()[0]
+	for sch := sfg.Schema; sch != nil && sch.Version()[0] == major; sch = sch.Predecessor() {
+		fields, err := docFields("", sch.Underlying().LookupPath(cue.ParsePath("schema")))
+		if err != nil {
+			return nil, fmt.Errorf("failed documenting schema %s: %w", sch.Version(), err)
+		}
+		vars.Schemas = append(vars.Schemas, tvars_docs_schema{
+			Version: sch.Version().String(),
+			Fields:  fields,
+		})
+	}
+	for sch := sfg.Schema; sch.Predecessor() != nil; sch = sch.Predecessor() {
+		vars.Changelog = append(vars.Changelog, compat.DiffSchemas(sch.Predecessor(), sch))
+	}
+
+	tmpl, ext := "docs_md.tmpl", ".md"
+	if j.HTML {
+		tmpl, ext = "docs_html.tmpl", ".html"
+	}
+	buf := new(bytes.Buffer)
+	if err := tmpls.Lookup(tmpl).Execute(buf, vars); err != nil {
+		return nil, fmt.Errorf("failed executing docs template: %w", err)
+	}
+	return codejen.NewFile(sfg.Schema.Lineage().Name()+"_docs"+ext, buf.Bytes(), j), nil
+}
+
+// docFields returns the documentation of each field in the struct v and, in
+// turn, of their fields, with paths prefixed by p.
+func docFields(p string, v cue.Value) ([]tvars_docs_field, error) {
+	iter, err := v.Fields(cue.Optional(true))
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []tvars_docs_field
+	for iter.Next() {
+		fp := iter.Selector().String()
+		if p != "" {
+			fp = p + "." + fp
+		}
+		fv := iter.Value()
+		f := tvars_docs_field{
+			Path:     fp,
+			Type:     docType(fv),
+			Required: !iter.IsOptional(),
+		}
+		for _, cg := range fv.Doc() {
+			f.Doc = strings.TrimSpace(f.Doc + " " + strings.Join(strings.Fields(cg.Text()), " "))
+		}
+
+		elem := fv
+		switch fv.IncompleteKind() {
+		case cue.StructKind:
+		case cue.ListKind:
+			elem = fv.LookupPath(cue.MakePath(cue.AnyIndex))
+			fp += "[]"
+		default:
+			if d, has := fv.Default(); has {
+				f.Default = docRepr(d)
+			}
+			if s := fmt.Sprint(fv); s != f.Type {
+				f.Constraints = s
+			}
+		}
+		fields = append(fields, f)
+
+		if elem.IncompleteKind() == cue.StructKind {
+			sub, err := docFields(fp, elem)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, sub...)
+		}
+	}
+	return fields, nil
+}
+
+// docType returns the name of the type of values accepted by v.
+func docType(v cue.Value) string {
+	switch k := v.IncompleteKind(); k {
+	case cue.StructKind:
+		return "object"
+	case cue.ListKind:
+		if elem := v.LookupPath(cue.MakePath(cue.AnyIndex)); elem.Exists() {
+			return "[]" + docType(elem)
+		}
+		return "list"
+	default:
+		return strings.ReplaceAll(strings.Trim(k.String(), "()"), "|", " | ")
+	}
+}
+
+// docRepr returns the CUE representation of a concrete value v, quoting
+// strings.
+func docRepr(v cue.Value) string {
+	if s, err := v.String(); err == nil {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,53 @@
+package codegen
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocsJenny_NoParams(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/folder_DocsJenny_NoParams",
+	})
+
+	test.RunOneToManyFromModule(
+		"testdata/codegen/schemas/folder",
+		LatestMajorsOrXJenny("", false, DocsJenny{}),
+	)
+}
+
+func TestDocsJenny_HTML(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/folder_DocsJenny_HTML",
+	})
+
+	test.RunOneToManyFromModule(
+		"testdata/codegen/schemas/folder",
+		LatestMajorsOrXJenny("", false, DocsJenny{HTML: true}),
+	)
+}
+
+func TestDocFields(t *testing.T) {
+	v := cuecontext.New().CompileString(`
+name: =~"^[a-z]+$"
+// Number of replicas.
+replicas?: int & >0 | *1
+mode: *"auto" | "manual"
+ports: [...{
+	port: uint16
+	protocol?: "TCP" | "UDP"
+}]
+`)
+	fields, err := docFields("spec", v)
+	require.NoError(t, err)
+	require.Equal(t, []tvars_docs_field{
+		{Path: "spec.name", Type: "string", Required: true, Constraints: `=~"^[a-z]+$"`},
+		{Path: "spec.replicas", Type: "int", Default: "1", Doc: "Number of replicas.", Constraints: "*1 | >0 & int"},
+		{Path: "spec.mode", Type: "string", Required: true, Default: `"auto"`, Constraints: `*"auto" | "manual"`},
+		{Path: "spec.ports", Type: "[]object", Required: true},
+		{Path: "spec.ports[].port", Type: "int", Required: true, Constraints: "uint16"},
+		{Path: "spec.ports[].protocol", Type: "string", Constraints: `"TCP" | "UDP"`},
+	}, fields)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/grafana/codejen"
 	"github.com/grafana/kindsys"
+	"github.com/grafana/thema"
 )
 
 // LatestMajorsOrXJenny returns a jenny that repeats the input for the latest in each major version.
@@ -49,10 +50,11 @@ func (j *lmox) Generate(kind kindsys.Kind) (codejen.Files, error) {
 		sfg.IsGroup = true
 	}
 
+	inner := innerForKind(j.inner, kind)
 	do := func(sfg SchemaForGen, infix string) (codejen.Files, error) {
-		f, err := j.inner.Generate(sfg)
+		f, err := inner.Generate(sfg)
 		if err != nil {
-			return nil, fmt.Errorf("%s jenny failed on %s schema for %s: %w", j.inner.JennyName(), sfg.Schema.Version(), kind.Props().Common().Name, err)
+			return nil, fmt.Errorf("%s jenny failed on %s schema for %s: %w", inner.JennyName(), sfg.Schema.Version(), kind.Props().Common().Name, err)
 		}
 		if f == nil || !f.Exists() {
 			return nil, nil
@@ -76,7 +78,7 @@ func (j *lmox) Generate(kind kindsys.Kind) (codejen.Files, error) {
 		}
 		major = int(sch.Version()[0])
 
-		sfg.Schema = sch.LatestInMajor()
+		sfg.Schema = latestInMajor(sch)
 		files, err := do(sfg, fmt.Sprintf("v%v", sch.Version()[0]))
 		if err != nil {
 			return nil, err
@@ -88,3 +90,13 @@ func (j *lmox) Generate(kind kindsys.Kind) (codejen.Files, error) {
 	}
 	return fl, nil
 }
+
+// latestInMajor returns the schema with the newest minor version within the
+// major version of sch. It stands in for [thema.Schema.LatestInMajor], which
+// returns the first schema of the next major version, if any.
+func latestInMajor(sch thema.Schema) thema.Schema {
+	for sch.Successor() != nil && sch.Successor().Version()[0] == sch.Version()[0] {
+		sch = sch.Successor()
+	}
+	return sch
+}
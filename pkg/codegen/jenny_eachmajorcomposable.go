@@ -9,6 +9,15 @@ import (
 	"github.com/grafana/kindsys"
 )
 
+// ComposableLatestMajorsOrXJenny returns a jenny that repeats the input for the
+// latest schema in each major version of a composable kind, as
+// [LatestMajorsOrXJenny] does for other kinds. Kinds less mature than stable
+// are generated only for their latest schema, in an "x" directory.
+//
+// Files are written beneath a directory for the plugin and one for the schema
+// interface, e.g. "testdata/dataquery/v1/" for the TestDataDataQuery kind. The
+// plugin is the kind's name less that of its schema interface.
+//
 // TODO remove this once there's a standard jenny for this...somewhere in core
 func ComposableLatestMajorsOrXJenny(parentdir string, inner codejen.OneToOne[SchemaForGen]) codejen.OneToMany[kindsys.Composable] {
 	if inner == nil {
@@ -31,50 +40,63 @@ func (j *clmox) JennyName() string {
 }
 
 func (j *clmox) Generate(k kindsys.Composable) (codejen.Files, error) {
+	// TODO remove this once codejen catches nils https://github.com/grafana/codejen/issues/5
+	if k == nil {
+		return nil, nil
+	}
+
+	comm := k.Props().Common()
 	si, err := kindsys.FindSchemaInterface(k.Def().Properties.SchemaInterface)
 	if err != nil {
 		return nil, err
 	}
 	sfg := SchemaForGen{
-		Name:    k.Lineage().Name(),
+		Name:    comm.Name,
 		IsGroup: si.IsGroup(),
 	}
 
-	// TODO adapt this once we figure out consistent naming
-	// nam := fmt.Sprintf("%s-%s", strings.ToLower(decl.Info.Meta().Id), strings.ToLower(sfg.Name))
-	nam := strings.ToLower(sfg.Name)
+	// composable kinds are named for the plugin implementing the schema
+	// interface, followed by the interface, e.g. TestDataDataQuery
+	pluginID := strings.ToLower(strings.TrimSuffix(comm.Name, si.Name()))
+	nam := fmt.Sprintf("%s-%s", pluginID, strings.ToLower(k.Lineage().Name()))
 
+	inner := innerForKind(j.inner, k)
 	do := func(sfg SchemaForGen, infix string) (codejen.Files, error) {
-		f, err := j.inner.Generate(sfg)
+		f, err := inner.Generate(sfg)
 		if err != nil {
-			return nil, fmt.Errorf("%s jenny failed on %s schema for %s: %w", j.inner.JennyName(), sfg.Schema.Version(), nam, err)
+			return nil, fmt.Errorf("%s jenny failed on %s schema for %s: %w", inner.JennyName(), sfg.Schema.Version(), nam, err)
 		}
 		if f == nil || !f.Exists() {
 			return nil, nil
 		}
 
-		f.RelativePath = filepath.Join(j.parentdir, strings.ToLower(strings.TrimSuffix(sfg.Name, si.Name())), strings.ToLower(si.Name()), infix, strings.ToLower(f.RelativePath))
+		f.RelativePath = filepath.Join(j.parentdir, pluginID, strings.ToLower(si.Name()), infix, strings.ToLower(f.RelativePath))
 		f.From = append(f.From, j)
 		return codejen.Files{*f}, nil
 	}
 
-	// TODO uncomment this latter half once plugins are fully converted to new system
-	// if comm.Maturity.Less(kindsys.MaturityStable) {
-	sfg.Schema = k.Lineage().Latest()
-	return do(sfg, "x")
-	// }
+	if comm.Maturity.Less(kindsys.MaturityStable) {
+		sfg.Schema = k.Lineage().Latest()
+		return do(sfg, "x")
+	}
+
+	var fl codejen.Files
+	major := -1
+	for sch := k.Lineage().First(); sch != nil; sch = sch.Successor() {
+		if int(sch.Version()[0]) == major {
+			continue
+		}
+		major = int(sch.Version()[0])
 
-	// var fl codejen.Files
-	// for sch := decl.Lineage.First(); sch != nil; sch = sch.Successor() {
-	// 	sfg.Schema = sch.LatestInMajor()
-	// 	files, err := do(sfg, fmt.Sprintf("v%v", sch.Version()[0]))
-	// 	if err != nil {
-	// 		return nil, err
-	// 	}
-	// 	fl = append(fl, files...)
-	// }
-	// if fl.Validate() != nil {
-	// 	return nil, fl.Validate()
-	// }
-	// return fl, nil
+		sfg.Schema = latestInMajor(sch)
+		files, err := do(sfg, fmt.Sprintf("v%v", sch.Version()[0]))
+		if err != nil {
+			return nil, err
+		}
+		fl = append(fl, files...)
+	}
+	if fl.Validate() != nil {
+		return nil, fl.Validate()
+	}
+	return fl, nil
 }
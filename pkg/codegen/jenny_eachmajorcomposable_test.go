@@ -0,0 +1,38 @@
+package codegen
+
+import (
+	"testing"
+)
+
+func TestComposableLatestMajorsOrXJenny_DataQuery(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/dataquery_ComposableLatestMajorsOrXJenny_GoTypesJenny",
+	})
+
+	test.RunComposableFromModule(
+		"testdata/codegen/composables/dataquery",
+		ComposableLatestMajorsOrXJenny("", GoTypesJenny{}),
+	)
+}
+
+func TestComposableLatestMajorsOrXJenny_PanelCfg(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/panelcfg_ComposableLatestMajorsOrXJenny_GoTypesJenny",
+	})
+
+	test.RunComposableFromModule(
+		"testdata/codegen/composables/panelcfg",
+		ComposableLatestMajorsOrXJenny("", GoTypesJenny{}),
+	)
+}
+
+func TestComposableLatestMajorsOrXJenny_TSTypesJenny(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/panelcfg_ComposableLatestMajorsOrXJenny_TSTypesJenny",
+	})
+
+	test.RunComposableFromModule(
+		"testdata/codegen/composables/panelcfg",
+		ComposableLatestMajorsOrXJenny("", TSTypesJenny{ImportMapper: JennyConfig{}.importMapper()}),
+	)
+}
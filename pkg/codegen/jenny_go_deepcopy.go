@@ -0,0 +1,334 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"strconv"
+
+	"github.com/grafana/codejen"
+	"github.com/grafana/kindsys"
+)
+
+// GoDeepCopyJenny is a [OneToOne] that produces DeepCopy and DeepCopyInto
+// methods for the Go types produced by another jenny, such as [GoTypesJenny]
+// or [GoResourceJenny], in the style of Kubernetes' deepcopy-gen. The methods
+// share no maps, slices or pointers between the original and the copy, and are
+// used by [kindsys.CopyResource].
+//
+// Methods are generated for every struct, map and slice type. The methods are
+// written to a separate file, so Inner must be the same jenny, with the same
+// options, as produces the types.
+//
+// A [kindsys.Core] kind can opt out of the generic implementation by setting
+// crd.deepCopy to "passthrough". The methods are then only generated for the
+// type named for the kind, and pass through to a hand-written function in the
+// same package, e.g. for a Folder kind:
+//
+//	func deepCopyFolder(in, out *Folder)
+//
+// The kind's setting is honored when GoDeepCopyJenny is run through
+// [LatestJenny] or [LatestMajorsOrXJenny]; otherwise, Passthrough applies.
+type GoDeepCopyJenny struct {
+	Inner       codejen.OneToOne[SchemaForGen]
+	Passthrough bool
+}
+
+var _ codejen.OneToOne[SchemaForGen] = &GoDeepCopyJenny{}
+
+func (j GoDeepCopyJenny) JennyName() string {
+	return "GoDeepCopyJenny"
+}
+
+func (j GoDeepCopyJenny) forKind(k kindsys.Kind) codejen.OneToOne[SchemaForGen] {
+	if props, is := k.Props().(kindsys.CoreProperties); is {
+		j.Passthrough = props.CRD.DeepCopy == "passthrough"
+	}
+	return j
+}
+
+func (j GoDeepCopyJenny) Generate(sfg SchemaForGen) (*codejen.File, error) {
+	if j.Inner == nil {
+		return nil, fmt.Errorf("no inner jenny to produce types")
+	}
+	types, err := j.Inner.Generate(sfg)
+	if err != nil {
+		return nil, err
+	}
+	if types == nil || !types.Exists() {
+		return nil, nil
+	}
+
+	fset := token.NewFileSet()
+	tf, err := parser.ParseFile(fset, types.RelativePath, types.Data, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing types generated by %s: %w", j.Inner.JennyName(), err)
+	}
+
+	g := &deepCopyGen{
+		fset:  fset,
+		types: make(map[string]ast.Expr),
+		needs: make(map[string]bool),
+		buf:   new(bytes.Buffer),
+	}
+	var names []string
+	for _, decl := range tf.Decls {
+		gd, is := decl.(*ast.GenDecl)
+		if !is || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if ts.Assign.IsValid() || ts.TypeParams != nil {
+				// aliases have the methods of their target, and generic
+				// types are not generated
+				continue
+			}
+			g.types[ts.Name.Name] = ts.Type
+			names = append(names, ts.Name.Name)
+		}
+	}
+
+	fmt.Fprintf(g.buf, "package %s\n\nimport (\n", tf.Name.Name)
+	for _, imp := range tf.Imports {
+		if imp.Name != nil {
+			fmt.Fprintf(g.buf, "\t%s %s\n", imp.Name.Name, imp.Path.Value)
+		} else {
+			fmt.Fprintf(g.buf, "\t%s\n", imp.Path.Value)
+		}
+	}
+	fmt.Fprintf(g.buf, "\t%q\n)\n", "github.com/grafana/kindsys")
+
+	for _, name := range names {
+		if j.Passthrough {
+			if name == sfg.Name {
+				g.passthrough(name)
+			}
+			continue
+		}
+		switch t := g.types[name].(type) {
+		case *ast.StructType, *ast.MapType:
+			g.methods(name)
+		case *ast.ArrayType:
+			if t.Len == nil {
+				g.methods(name)
+			}
+		}
+	}
+	if g.err != nil {
+		return nil, g.err
+	}
+
+	path := sfg.Schema.Lineage().Name() + "_deepcopy_gen.go"
+	b, err := PostprocessGoFile(GenGoFile{
+		Path: path,
+		In:   g.buf.Bytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codejen.NewFile(path, b, j), nil
+}
+
+// deepCopyGen writes deep copy methods for the types declared in a Go file.
+type deepCopyGen struct {
+	fset *token.FileSet
+	// types are the types declared in the file, by name.
+	types map[string]ast.Expr
+	// needs memoizes needsCopy for declared types.
+	needs map[string]bool
+	buf   *bytes.Buffer
+	// n numbers loop variables, to keep those of nested loops distinct.
+	n   int
+	err error
+}
+
+func (g *deepCopyGen) methods(name string) {
+	g.n = 0
+	fmt.Fprintf(g.buf, `
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *%[1]s) DeepCopyInto(out *%[1]s) {
+	*out = *in
+`, name)
+	if _, is := g.types[name].(*ast.StructType); is {
+		// fields are selected through the pointers
+		g.copy(g.types[name], "in", "out")
+	} else {
+		g.copy(g.types[name], "(*in)", "(*out)")
+	}
+	fmt.Fprintf(g.buf, `}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *%[1]s) DeepCopy() *%[1]s {
+	if in == nil {
+		return nil
+	}
+	out := new(%[1]s)
+	in.DeepCopyInto(out)
+	return out
+}
+`, name)
+}
+
+func (g *deepCopyGen) passthrough(name string) {
+	fmt.Fprintf(g.buf, `
+// DeepCopyInto copies the receiver into out by calling deepCopy%[1]s, which
+// must be provided in this package. in must be non-nil.
+func (in *%[1]s) DeepCopyInto(out *%[1]s) {
+	deepCopy%[1]s(in, out)
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *%[1]s) DeepCopy() *%[1]s {
+	if in == nil {
+		return nil
+	}
+	out := new(%[1]s)
+	in.DeepCopyInto(out)
+	return out
+}
+`, name)
+}
+
+// copy writes statements that make the expression out, of type t and already
+// assigned the value of the expression in, a deep copy of in.
+func (g *deepCopyGen) copy(t ast.Expr, in, out string) {
+	if !g.needsCopy(t) {
+		return
+	}
+	if isAny(t) {
+		fmt.Fprintf(g.buf, "%s = kindsys.DeepCopyValue(%s)\n", out, in)
+		return
+	}
+	switch x := t.(type) {
+	case *ast.ParenExpr:
+		g.copy(x.X, in, out)
+	case *ast.Ident, *ast.SelectorExpr:
+		// a declared type, or one from another package such as
+		// kindsys.BasicMetadataObject, with its own DeepCopyInto method
+		fmt.Fprintf(g.buf, "%s.DeepCopyInto(&%s)\n", in, out)
+	case *ast.StarExpr:
+		fmt.Fprintf(g.buf, "if %[1]s != nil {\n%[2]s = new(%[3]s)\n*%[2]s = *%[1]s\n", in, out, g.expr(x.X))
+		g.copy(x.X, "(*"+in+")", "(*"+out+")")
+		fmt.Fprintf(g.buf, "}\n")
+	case *ast.ArrayType:
+		i := g.name("i")
+		if x.Len == nil {
+			fmt.Fprintf(g.buf, "if %[1]s != nil {\n%[2]s = make(%[3]s, len(%[1]s))\ncopy(%[2]s, %[1]s)\n", in, out, g.expr(x))
+		}
+		if g.needsCopy(x.Elt) {
+			fmt.Fprintf(g.buf, "for %s := range %s {\n", i, in)
+			g.copy(x.Elt, in+"["+i+"]", out+"["+i+"]")
+			fmt.Fprintf(g.buf, "}\n")
+		}
+		if x.Len == nil {
+			fmt.Fprintf(g.buf, "}\n")
+		}
+	case *ast.MapType:
+		key, val := g.name("key"), g.name("val")
+		fmt.Fprintf(g.buf, "if %[1]s != nil {\n%[2]s = make(%[3]s, len(%[1]s))\nfor %[4]s, %[5]s := range %[1]s {\n", in, out, g.expr(x), key, val)
+		switch {
+		case isAny(x.Value):
+			val = "kindsys.DeepCopyValue(" + val + ")"
+		case g.needsCopy(x.Value):
+			cp := g.name("cp")
+			fmt.Fprintf(g.buf, "%s := %s\n", cp, val)
+			g.copy(x.Value, val, cp)
+			val = cp
+		}
+		fmt.Fprintf(g.buf, "%s[%s] = %s\n}\n}\n", out, key, val)
+	case *ast.StructType:
+		for _, f := range x.Fields.List {
+			names := f.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{embeddedName(f.Type)}
+			}
+			for _, name := range names {
+				g.copy(f.Type, in+"."+name.Name, out+"."+name.Name)
+			}
+		}
+	default:
+		g.err = fmt.Errorf("unsupported type %s", g.expr(t))
+	}
+}
+
+// needsCopy indicates whether a value of type t shares memory with its
+// assigned copies, so must be deep copied.
+func (g *deepCopyGen) needsCopy(t ast.Expr) bool {
+	switch x := t.(type) {
+	case *ast.ParenExpr:
+		return g.needsCopy(x.X)
+	case *ast.Ident:
+		if isAny(x) {
+			return true
+		}
+		decl, declared := g.types[x.Name]
+		if !declared {
+			// predeclared types are all scalars
+			return false
+		}
+		if needs, seen := g.needs[x.Name]; seen {
+			return needs
+		}
+		// guard against recursive types, which can only recurse through
+		// pointers, slices or maps, which need copying anyway
+		g.needs[x.Name] = false
+		g.needs[x.Name] = g.needsCopy(decl)
+		return g.needs[x.Name]
+	case *ast.SelectorExpr:
+		if pkg, is := x.X.(*ast.Ident); is && pkg.Name == "time" {
+			return x.Sel.Name != "Time" && x.Sel.Name != "Duration"
+		}
+		return true
+	case *ast.StarExpr, *ast.MapType, *ast.InterfaceType:
+		return true
+	case *ast.ArrayType:
+		return x.Len == nil || g.needsCopy(x.Elt)
+	case *ast.StructType:
+		for _, f := range x.Fields.List {
+			if g.needsCopy(f.Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// name returns a new variable name with the provided prefix.
+func (g *deepCopyGen) name(prefix string) string {
+	g.n++
+	return prefix + strconv.Itoa(g.n)
+}
+
+func (g *deepCopyGen) expr(x ast.Expr) string {
+	buf := new(bytes.Buffer)
+	if err := printer.Fprint(buf, g.fset, x); err != nil {
+		g.err = err
+	}
+	return buf.String()
+}
+
+// isAny indicates whether x is any, or another interface type.
+func isAny(x ast.Expr) bool {
+	if _, is := x.(*ast.InterfaceType); is {
+		return true
+	}
+	id, is := x.(*ast.Ident)
+	return is && id.Name == "any"
+}
+
+// embeddedName returns the field name of an embedded field of type t.
+func embeddedName(t ast.Expr) *ast.Ident {
+	switch x := t.(type) {
+	case *ast.StarExpr:
+		return embeddedName(x.X)
+	case *ast.SelectorExpr:
+		return x.Sel
+	case *ast.Ident:
+		return x
+	}
+	return ast.NewIdent("_")
+}
@@ -0,0 +1,40 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoDeepCopyJenny_GoResourceJenny(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/folder_GoDeepCopyJenny_GoResourceJenny",
+	})
+
+	test.RunOneToOneFromModule(
+		"testdata/codegen/schemas/folder",
+		LatestJenny("parent", GoDeepCopyJenny{Inner: GoResourceJenny{}}),
+	)
+}
+
+func TestGoDeepCopyJenny_GoTypesJenny(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/folder_GoDeepCopyJenny_GoTypesJenny",
+	})
+
+	test.RunOneToOneFromModule(
+		"testdata/codegen/schemas/folder",
+		LatestJenny("parent", GoDeepCopyJenny{Inner: GoTypesJenny{}}),
+	)
+}
+
+func TestGoDeepCopyJenny_Passthrough(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{})
+	kind, err := test.ModuleToCoreKind("testdata/codegen/schemas/folder")
+	require.NoError(t, err)
+
+	f, err := GoDeepCopyJenny{Inner: GoResourceJenny{}, Passthrough: true}.Generate(ForLatestSchema(kind))
+	require.NoError(t, err)
+	require.Contains(t, string(f.Data), "func (in *Folder) DeepCopyInto(out *Folder) {\n\tdeepCopyFolder(in, out)\n}")
+	require.NotContains(t, string(f.Data), "func (in *Spec)")
+}
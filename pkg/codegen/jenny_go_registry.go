@@ -0,0 +1,150 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/grafana/codejen"
+	"github.com/grafana/kindsys"
+)
+
+// GoRegistryJenny is a [ManyToMany] that produces a Go package exposing every
+// provided kind, bound and ready for use. The CUE files of each kind definition
+// are written beneath the package's _cue directory, in a directory named for
+// the kind's machine name, and embedded in the package, so the kinds are loaded
+// without access to the filesystem from which they were generated. As the
+// directory begins with "_", [kindsys.LoadKinds] does not load the copies.
+//
+// The package has a Registry type, created by its New function, with a method
+// named for each kind returning the kind as a [kindsys.Core], [kindsys.Custom]
+// or [kindsys.Composable], and an All method returning every kind, ordered by
+// name. For example, for a Folder core kind:
+//
+//	reg, err := registry.New()
+//	if err != nil {
+//		return err
+//	}
+//	folder := reg.Folder()
+//
+// Source must be the filesystem from which the kinds were loaded, as by
+// [kindsys.LoadKinds] or [kindsys.LoadKind], as each kind definition's files
+// are read from it, in the directory recorded in the kind's [kindsys.Def]. Kinds
+// not loaded from a filesystem cannot be generated. For this reason, GoRegistryJenny cannot be selected in a
+// [Config], and is only run by Go programs.
+type GoRegistryJenny struct {
+	// Package is the name of the generated Go package. It defaults to
+	// "registry".
+	Package string
+
+	// Source is the filesystem from which the kinds were loaded.
+	Source fs.FS
+}
+
+var _ codejen.ManyToMany[kindsys.Kind] = &GoRegistryJenny{}
+
+func (j GoRegistryJenny) JennyName() string {
+	return "GoRegistryJenny"
+}
+
+func (j GoRegistryJenny) Generate(kinds ...kindsys.Kind) (codejen.Files, error) {
+	if j.Source == nil {
+		return nil, fmt.Errorf("no source filesystem from which to embed kinds")
+	}
+
+	vars := tvars_go_registry{
+		Package: j.Package,
+	}
+	if vars.Package == "" {
+		vars.Package = "registry"
+	}
+
+	kinds = append([]kindsys.Kind(nil), kinds...)
+	sort.Slice(kinds, func(i, k int) bool {
+		return kinds[i].Name() < kinds[k].Name()
+	})
+	var files codejen.Files
+	machineNames := make(map[string]string)
+	for i, k := range kinds {
+		comm := k.Props().Common()
+		rk := tvars_go_registry_kind{
+			Name:        comm.Name,
+			GoName:      strings.ReplaceAll(comm.Name, "-", "_"),
+			MachineName: comm.MachineName,
+		}
+		switch k.(type) {
+		case kindsys.Core:
+			rk.Category = "Core"
+		case kindsys.Custom:
+			rk.Category = "Custom"
+		case kindsys.Composable:
+			rk.Category = "Composable"
+		}
+		if rk.GoName == "All" {
+			return nil, fmt.Errorf("kind name %s conflicts with the Registry's All method", comm.Name)
+		}
+		if i > 0 && kinds[i-1].Name() == comm.Name {
+			return nil, fmt.Errorf("multiple kinds named %s", comm.Name)
+		}
+		if other, has := machineNames[comm.MachineName]; has {
+			return nil, fmt.Errorf("kinds %s and %s both have machine name %s", other, comm.Name, comm.MachineName)
+		}
+		machineNames[comm.MachineName] = comm.Name
+
+		dir, err := kindSourceDir(k)
+		if err != nil {
+			return nil, err
+		}
+		ents, err := fs.ReadDir(j.Source, dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading source of kind %s: %w", comm.Name, err)
+		}
+		for _, ent := range ents {
+			if ent.IsDir() || !strings.HasSuffix(ent.Name(), ".cue") {
+				continue
+			}
+			b, err := fs.ReadFile(j.Source, path.Join(dir, ent.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("failed reading source of kind %s: %w", comm.Name, err)
+			}
+			files = append(files, *codejen.NewFile(path.Join("_cue", comm.MachineName, ent.Name()), b, j))
+		}
+		vars.Kinds = append(vars.Kinds, rk)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := tmpls.Lookup("go_registry.tmpl").Execute(buf, vars); err != nil {
+		return nil, fmt.Errorf("failed executing go registry template: %w", err)
+	}
+	b, err := PostprocessGoFile(GenGoFile{
+		Path: "registry_gen.go",
+		In:   buf.Bytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(codejen.Files{*codejen.NewFile("registry_gen.go", b, j)}, files...), nil
+}
+
+// kindSourceDir returns the directory from which the kind's definition was
+// loaded, relative to the root of the filesystem it was loaded from.
+func kindSourceDir(k kindsys.Kind) (string, error) {
+	var dir string
+	switch dk := k.(type) {
+	case kindsys.Core:
+		dir = dk.Def().Dir
+	case kindsys.Custom:
+		dir = dk.Def().Dir
+	case kindsys.Composable:
+		dir = dk.Def().Dir
+	default:
+		return "", fmt.Errorf("kind %s is of unknown category %T", k.Name(), k)
+	}
+	if dir == "" {
+		return "", fmt.Errorf("kind %s was not loaded from a filesystem", k.Name())
+	}
+	return dir, nil
+}
@@ -0,0 +1,53 @@
+package codegen
+
+import (
+	"os"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/grafana/thema"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/kindsys"
+)
+
+func TestGoRegistryJenny_NoParams(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/folder_GoRegistryJenny_NoParams",
+	})
+
+	test.RunManyToManyFromModule(
+		"testdata/codegen/schemas/folder",
+		GoRegistryJenny{Source: os.DirFS("testdata/codegen/schemas/folder")},
+	)
+}
+
+func TestGoRegistryJenny_AllCategories(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/all_GoRegistryJenny_NoParams",
+	})
+
+	test.RunManyToManyFromDir(
+		"testdata/codegen",
+		GoRegistryJenny{Package: "kinds", Source: os.DirFS("testdata/codegen")},
+	)
+}
+
+func TestGoRegistryJenny_NotLoaded(t *testing.T) {
+	ctx := cuecontext.New()
+	def, err := kindsys.ToDef[kindsys.CoreProperties](ctx.CompileString(`
+name:        "Thing"
+maturity:    "experimental"
+description: "A thing."
+lineage: schemas: [{
+	version: [0, 0]
+	schema: spec: title: string
+}]
+`))
+	require.NoError(t, err)
+	k, err := kindsys.BindCore(thema.NewRuntime(ctx), def)
+	require.NoError(t, err)
+
+	_, err = GoRegistryJenny{Source: os.DirFS("testdata/codegen")}.Generate(k)
+	require.EqualError(t, err, "kind Thing was not loaded from a filesystem")
+}
@@ -0,0 +1,104 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"github.com/dave/dst/dstutil"
+	"github.com/grafana/codejen"
+)
+
+// GoResourceJenny is a [OneToOne] that produces Go types for the provided
+// [thema.Schema] of a [kindsys.Core] or [kindsys.Custom] kind, together with a
+// struct named for the kind that implements [kindsys.Resource]. The struct can
+// be used as the type parameter to [kindsys.BindCoreResource] or
+// [kindsys.BindCustomResource].
+//
+// The schema's top-level fields are generated as separate types, as for a
+// lineage that is a group: Spec, Status, and so on. The resource struct embeds
+// [kindsys.BasicMetadataObject] for its metadata, and has a field of each of
+// those types but Metadata. As GoResourceJenny's output includes the types
+// produced by [GoTypesJenny], the two should not be generated into the same
+// package.
+//
+// No file is produced for schemas without a spec field, such as those of
+// [kindsys.Composable] kinds.
+type GoResourceJenny struct {
+	ApplyFuncs       []dstutil.ApplyFunc
+	ExpandReferences bool
+}
+
+var _ codejen.OneToOne[SchemaForGen] = &GoResourceJenny{}
+
+func (j GoResourceJenny) JennyName() string {
+	return "GoResourceJenny"
+}
+
+func (j GoResourceJenny) Generate(sfg SchemaForGen) (*codejen.File, error) {
+	// the schema unified with the lineage's joinSchema, as thema generates from
+	schema := sfg.Schema.Underlying().LookupPath(cue.MakePath(cue.Hid("_#schema", "github.com/grafana/thema")))
+	if !schema.LookupPath(cue.ParsePath("spec")).Exists() {
+		return nil, nil
+	}
+
+	vars := tvars_go_resource{
+		Name:    sfg.Name,
+		Version: sfg.Schema.Version().String(),
+	}
+	iter, err := schema.Fields(cue.Optional(true))
+	if err != nil {
+		return nil, err
+	}
+	for iter.Next() {
+		switch name := iter.Selector().Unquoted(); name {
+		case "metadata", "spec":
+		default:
+			vars.Subresources = append(vars.Subresources, tvars_go_field{
+				Name:   name,
+				GoName: strings.ToUpper(name[:1]) + name[1:],
+			})
+		}
+	}
+
+	types, err := GoTypesJenny{
+		ApplyFuncs:       j.ApplyFuncs,
+		ExpandReferences: j.ExpandReferences,
+	}.Generate(SchemaForGen{
+		Name:    sfg.Name,
+		Schema:  sfg.Schema,
+		IsGroup: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := tmpls.Lookup("go_resource.tmpl").Execute(buf, vars); err != nil {
+		return nil, fmt.Errorf("failed executing go resource template: %w", err)
+	}
+
+	loc := packageClause.FindIndex(types.Data)
+	if loc == nil {
+		return nil, fmt.Errorf("no package clause in generated types for %s", sfg.Name)
+	}
+	src := new(bytes.Buffer)
+	src.Write(types.Data[:loc[1]])
+	src.WriteString("\nimport \"github.com/grafana/kindsys\"\n")
+	src.Write(types.Data[loc[1]:])
+	src.WriteString("\n")
+	src.Write(buf.Bytes())
+
+	b, err := PostprocessGoFile(GenGoFile{
+		Path: sfg.Schema.Lineage().Name() + "_resource_gen.go",
+		In:   src.Bytes(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed formatting generated resource for %s: %w", sfg.Name, err)
+	}
+	return codejen.NewFile(sfg.Schema.Lineage().Name()+"_resource_gen.go", b, j), nil
+}
+
+var packageClause = regexp.MustCompile(`(?m)^package \w+\n`)
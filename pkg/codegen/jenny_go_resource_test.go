@@ -0,0 +1,16 @@
+package codegen
+
+import (
+	"testing"
+)
+
+func TestGoResourceJenny_NoParams(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/folder_GoResourceJenny_NoParams",
+	})
+
+	test.RunOneToOneFromModule(
+		"testdata/codegen/schemas/folder",
+		LatestJenny("parent", GoResourceJenny{}),
+	)
+}
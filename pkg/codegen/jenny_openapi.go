@@ -0,0 +1,433 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/cuecontext"
+	copenapi "cuelang.org/go/encoding/openapi"
+	"github.com/grafana/codejen"
+	"github.com/grafana/kindsys"
+	"github.com/grafana/thema"
+	"github.com/grafana/thema/encoding/openapi"
+)
+
+// OpenAPIJenny is a [ManyToOne] that produces an OpenAPI 3 document, in JSON,
+// describing the Kubernetes-style REST API of the [kindsys.Core] and
+// [kindsys.Custom] kinds in a group. Other kinds are ignored.
+//
+// Every schema in each kind's lineage is served as a separate API version,
+// named by [kindsys.APIVersion] as for [CRDJenny]. For each version, the
+// document has paths to list, get, create, update, patch and delete resources
+// of the kind, and to get, update and patch their status. A resource's schema,
+// and that of a list of resources, are components named for the kind and
+// version, e.g. "Folder.v0-1" and "FolderList.v0-1". Component schemas are
+// generated by thema's OpenAPI encoder, with references expanded.
+//
+// The document is written to a file named for the group, e.g.
+// "folder.core.grafana.com.openapi.json".
+type OpenAPIJenny struct {
+	// Group is the group for which the document is generated. Kinds of other
+	// groups are ignored. If empty, all the kinds must be of the same group.
+	Group string
+
+	// Version is the version of the document given in its info object. It
+	// defaults to "0.0.0".
+	Version string
+}
+
+var _ codejen.ManyToOne[kindsys.Kind] = &OpenAPIJenny{}
+
+func (j OpenAPIJenny) JennyName() string {
+	return "OpenAPIJenny"
+}
+
+func (j OpenAPIJenny) Generate(kinds ...kindsys.Kind) (*codejen.File, error) {
+	group := j.Group
+	var rks []kindsys.ResourceKind
+	for _, k := range kinds {
+		rk, ok := k.(kindsys.ResourceKind)
+		if !ok {
+			continue
+		}
+		switch {
+		case group == "":
+			group = rk.Group()
+		case rk.Group() == group:
+		case j.Group != "":
+			continue
+		default:
+			return nil, fmt.Errorf("kinds of multiple groups, %s and %s; one must be chosen", group, rk.Group())
+		}
+		rks = append(rks, rk)
+	}
+	if len(rks) == 0 {
+		return nil, nil
+	}
+	sort.Slice(rks, func(i, k int) bool {
+		return rks[i].Name() < rks[k].Name()
+	})
+
+	version := j.Version
+	if version == "" {
+		version = "0.0.0"
+	}
+
+	var paths []ast.Decl
+	schemas := []ast.Decl{
+		&ast.Field{Label: ast.NewString("ObjectMeta"), Value: objectMetaSchema()},
+		&ast.Field{Label: ast.NewString("ListMeta"), Value: listMetaSchema()},
+		&ast.Field{Label: ast.NewString("Status"), Value: statusSchema()},
+	}
+	for _, rk := range rks {
+		comm := rk.Props().Common()
+		scope, ok := crdScope(rk)
+		if !ok {
+			scope = "Namespaced"
+		}
+		for sch := rk.Lineage().First(); sch != nil; sch = sch.Successor() {
+			apiVersion := kindsys.APIVersion(sch.Version())
+			name := comm.Name + "." + apiVersion
+			listName := comm.Name + "List." + apiVersion
+
+			res, err := openapiResourceSchema(sch, comm.Name, group+"/"+apiVersion)
+			if err != nil {
+				return nil, fmt.Errorf("failed generating openapi for schema %s of %s: %w", sch.Version(), comm.Name, err)
+			}
+			schemas = append(schemas,
+				&ast.Field{Label: ast.NewString(name), Value: res},
+				&ast.Field{Label: ast.NewString(listName), Value: listSchema(comm.Name+"List", group+"/"+apiVersion, name)},
+			)
+
+			ops := openapiOps{
+				kind:     comm.Name,
+				version:  apiVersion,
+				resource: name,
+				list:     listName,
+			}
+			base := "/apis/" + group + "/" + apiVersion
+			coll := base + "/" + comm.PluralMachineName
+			if scope == "Namespaced" {
+				paths = append(paths, &ast.Field{
+					Label: ast.NewString(coll),
+					Value: ast.NewStruct("get", ops.listAll()),
+				})
+				coll = base + "/namespaces/{namespace}/" + comm.PluralMachineName
+				ops.params = append(ops.params, paramRef("namespace"))
+			}
+			paths = append(paths,
+				&ast.Field{Label: ast.NewString(coll), Value: ops.collection()},
+				&ast.Field{Label: ast.NewString(coll + "/{name}"), Value: ops.item()},
+				&ast.Field{Label: ast.NewString(coll + "/{name}/status"), Value: ops.status()},
+			)
+		}
+	}
+
+	doc := ast.NewStruct(
+		"openapi", ast.NewString("3.0.3"),
+		"info", ast.NewStruct(
+			"title", ast.NewString(group),
+			"version", ast.NewString(version),
+		),
+		"paths", &ast.StructLit{Elts: paths},
+		"components", ast.NewStruct(
+			"schemas", &ast.StructLit{Elts: schemas},
+			"parameters", openapiParams(),
+		),
+	)
+
+	v := cuecontext.New().BuildExpr(doc)
+	if v.Err() != nil {
+		return nil, v.Err()
+	}
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if err = json.Indent(buf, b, "", "  "); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return codejen.NewFile(group+".openapi.json", buf.Bytes(), j), nil
+}
+
+// openapiResourceSchema returns the schema of a resource of the kind named
+// name, with the schema sch, as an OpenAPI schema for objects of the API
+// version apiVersion.
+func openapiResourceSchema(sch thema.Schema, name, apiVersion string) (*ast.StructLit, error) {
+	f, err := openapi.GenerateSchema(sch, &openapi.Config{
+		Config: &copenapi.Config{
+			ExpandReferences: true,
+		},
+		RootName: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var root *ast.StructLit
+	if len(f.Decls) == 1 {
+		if doc, is := f.Decls[0].(*ast.StructLit); is {
+			comps, _ := lookupField(doc, "components").(*ast.StructLit)
+			schemas, _ := lookupField(comps, "schemas").(*ast.StructLit)
+			root, _ = lookupField(schemas, name).(*ast.StructLit)
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("no schema component named %s in generated openapi", name)
+	}
+
+	// objects are served with Kubernetes' ObjectMeta, in which kindsys'
+	// metadata is stored, and their status is managed through the status
+	// subresource, so is not required
+	props, _ := lookupField(root, "properties").(*ast.StructLit)
+	if props == nil {
+		return nil, fmt.Errorf("no properties in schema component %s", name)
+	}
+	deleteField(props, "metadata")
+	props.Elts = append([]ast.Decl{
+		&ast.Field{Label: ast.NewString("apiVersion"), Value: ast.NewStruct(
+			"type", ast.NewString("string"),
+			"enum", ast.NewList(ast.NewString(apiVersion)),
+		)},
+		&ast.Field{Label: ast.NewString("kind"), Value: ast.NewStruct(
+			"type", ast.NewString("string"),
+			"enum", ast.NewList(ast.NewString(name)),
+		)},
+		&ast.Field{Label: ast.NewString("metadata"), Value: schemaRef("ObjectMeta")},
+	}, props.Elts...)
+	if req, is := lookupField(root, "required").(*ast.ListLit); is {
+		elts := []ast.Expr{ast.NewString("apiVersion"), ast.NewString("kind")}
+		for _, elt := range req.Elts {
+			if s := stringLit(elt); s != "metadata" && s != "status" {
+				elts = append(elts, elt)
+			}
+		}
+		req.Elts = elts
+	}
+	return root, nil
+}
+
+// listSchema returns the OpenAPI schema of a list of the resources described
+// by the schema component named item.
+func listSchema(kind, apiVersion, item string) *ast.StructLit {
+	return ast.NewStruct(
+		"type", ast.NewString("object"),
+		"required", ast.NewList(ast.NewString("items")),
+		"properties", ast.NewStruct(
+			"apiVersion", ast.NewStruct(
+				"type", ast.NewString("string"),
+				"enum", ast.NewList(ast.NewString(apiVersion)),
+			),
+			"kind", ast.NewStruct(
+				"type", ast.NewString("string"),
+				"enum", ast.NewList(ast.NewString(kind)),
+			),
+			"metadata", schemaRef("ListMeta"),
+			"items", ast.NewStruct(
+				"type", ast.NewString("array"),
+				"items", schemaRef(item),
+			),
+		),
+	)
+}
+
+// objectMetaSchema returns an OpenAPI schema for the commonly used fields of
+// Kubernetes' ObjectMeta.
+func objectMetaSchema() *ast.StructLit {
+	return ast.NewStruct(
+		"type", ast.NewString("object"),
+		"properties", ast.NewStruct(
+			"name", stringSchema(),
+			"generateName", stringSchema(),
+			"namespace", stringSchema(),
+			"uid", stringSchema(),
+			"resourceVersion", stringSchema(),
+			"generation", ast.NewStruct("type", ast.NewString("integer"), "format", ast.NewString("int64")),
+			"creationTimestamp", ast.NewStruct("type", ast.NewString("string"), "format", ast.NewString("date-time")),
+			"deletionTimestamp", ast.NewStruct("type", ast.NewString("string"), "format", ast.NewString("date-time")),
+			"labels", ast.NewStruct("type", ast.NewString("object"), "additionalProperties", stringSchema()),
+			"annotations", ast.NewStruct("type", ast.NewString("object"), "additionalProperties", stringSchema()),
+			"finalizers", ast.NewStruct("type", ast.NewString("array"), "items", stringSchema()),
+		),
+		"x-kubernetes-preserve-unknown-fields", ast.NewBool(true),
+	)
+}
+
+// listMetaSchema returns an OpenAPI schema for Kubernetes' ListMeta.
+func listMetaSchema() *ast.StructLit {
+	return ast.NewStruct(
+		"type", ast.NewString("object"),
+		"properties", ast.NewStruct(
+			"resourceVersion", stringSchema(),
+			"continue", stringSchema(),
+			"remainingItemCount", ast.NewStruct("type", ast.NewString("integer"), "format", ast.NewString("int64")),
+		),
+	)
+}
+
+// statusSchema returns an OpenAPI schema for Kubernetes' Status, returned by
+// deletions and on failure.
+func statusSchema() *ast.StructLit {
+	return ast.NewStruct(
+		"type", ast.NewString("object"),
+		"properties", ast.NewStruct(
+			"apiVersion", stringSchema(),
+			"kind", stringSchema(),
+			"status", stringSchema(),
+			"message", stringSchema(),
+			"reason", stringSchema(),
+			"code", ast.NewStruct("type", ast.NewString("integer"), "format", ast.NewString("int32")),
+		),
+	)
+}
+
+// openapiParams returns the parameter components referenced by operations.
+func openapiParams() *ast.StructLit {
+	param := func(name, in, typ, desc string) ast.Expr {
+		return ast.NewStruct(
+			"name", ast.NewString(name),
+			"in", ast.NewString(in),
+			"description", ast.NewString(desc),
+			"required", ast.NewBool(in == "path"),
+			"schema", ast.NewStruct("type", ast.NewString(typ)),
+		)
+	}
+	return ast.NewStruct(
+		"namespace", param("namespace", "path", "string", "The namespace of the resources."),
+		"name", param("name", "path", "string", "The name of the resource."),
+		"labelSelector", param("labelSelector", "query", "string", "Selects resources by their labels."),
+		"fieldSelector", param("fieldSelector", "query", "string", "Selects resources by their fields."),
+		"limit", param("limit", "query", "integer", "The maximum number of resources to return."),
+		"continue", param("continue", "query", "string", "The continue token of a previous, limited, list."),
+		"dryRun", param("dryRun", "query", "string", "If All, changes are validated but not persisted."),
+	)
+}
+
+// openapiOps builds the operations on the resources of one version of a kind.
+type openapiOps struct {
+	kind, version  string
+	resource, list string
+	// params are common to every operation.
+	params []ast.Expr
+}
+
+// listAll returns the operation listing resources in all namespaces.
+func (o openapiOps) listAll() ast.Expr {
+	return o.op("list"+o.kind+"ForAllNamespaces", "List "+o.kind+" resources in all namespaces.", o.queryParams(), nil,
+		"200", response("OK", schemaRef(o.list)),
+	)
+}
+
+// collection returns the path item of a collection of resources.
+func (o openapiOps) collection() ast.Expr {
+	return ast.NewStruct(
+		"get", o.op("list"+o.kind, "List "+o.kind+" resources.", o.queryParams(), nil,
+			"200", response("OK", schemaRef(o.list)),
+		),
+		"post", o.op("create"+o.kind, "Create a "+o.kind+".", []ast.Expr{paramRef("dryRun")}, jsonBody(schemaRef(o.resource)),
+			"200", response("OK", schemaRef(o.resource)),
+			"201", response("Created", schemaRef(o.resource)),
+		),
+	)
+}
+
+// item returns the path item of a single resource.
+func (o openapiOps) item() ast.Expr {
+	return ast.NewStruct(
+		"get", o.op("get"+o.kind, "Get a "+o.kind+".", nil, nil,
+			"200", response("OK", schemaRef(o.resource)),
+		),
+		"put", o.op("update"+o.kind, "Replace a "+o.kind+".", []ast.Expr{paramRef("dryRun")}, jsonBody(schemaRef(o.resource)),
+			"200", response("OK", schemaRef(o.resource)),
+		),
+		"patch", o.op("patch"+o.kind, "Patch a "+o.kind+".", []ast.Expr{paramRef("dryRun")}, patchBody(),
+			"200", response("OK", schemaRef(o.resource)),
+		),
+		"delete", o.op("delete"+o.kind, "Delete a "+o.kind+".", []ast.Expr{paramRef("dryRun")}, nil,
+			"200", response("OK", schemaRef("Status")),
+		),
+		"parameters", ast.NewList(paramRef("name")),
+	)
+}
+
+// status returns the path item of the status subresource of a resource.
+func (o openapiOps) status() ast.Expr {
+	return ast.NewStruct(
+		"get", o.op("get"+o.kind+"Status", "Get the status of a "+o.kind+".", nil, nil,
+			"200", response("OK", schemaRef(o.resource)),
+		),
+		"put", o.op("update"+o.kind+"Status", "Replace the status of a "+o.kind+".", []ast.Expr{paramRef("dryRun")}, jsonBody(schemaRef(o.resource)),
+			"200", response("OK", schemaRef(o.resource)),
+		),
+		"patch", o.op("patch"+o.kind+"Status", "Patch the status of a "+o.kind+".", []ast.Expr{paramRef("dryRun")}, patchBody(),
+			"200", response("OK", schemaRef(o.resource)),
+		),
+		"parameters", ast.NewList(paramRef("name")),
+	)
+}
+
+func (o openapiOps) queryParams() []ast.Expr {
+	return []ast.Expr{paramRef("labelSelector"), paramRef("fieldSelector"), paramRef("limit"), paramRef("continue")}
+}
+
+// op returns an operation with the given parameters, request body, if any, and
+// responses, as pairs of status code and response.
+func (o openapiOps) op(id, summary string, params []ast.Expr, body ast.Expr, responses ...interface{}) ast.Expr {
+	fields := []interface{}{
+		"operationId", ast.NewString(id + "." + o.version),
+		"summary", ast.NewString(summary),
+		"tags", ast.NewList(ast.NewString(o.kind)),
+	}
+	if params = append(append([]ast.Expr(nil), o.params...), params...); len(params) > 0 {
+		fields = append(fields, "parameters", ast.NewList(params...))
+	}
+	if body != nil {
+		fields = append(fields, "requestBody", body)
+	}
+	fields = append(fields, "responses", ast.NewStruct(append(responses, "default", response("Error", schemaRef("Status")))...))
+	return ast.NewStruct(fields...)
+}
+
+func response(desc string, schema ast.Expr) ast.Expr {
+	return ast.NewStruct(
+		"description", ast.NewString(desc),
+		"content", ast.NewStruct("application/json", ast.NewStruct("schema", schema)),
+	)
+}
+
+func jsonBody(schema ast.Expr) ast.Expr {
+	return ast.NewStruct(
+		"required", ast.NewBool(true),
+		"content", ast.NewStruct("application/json", ast.NewStruct("schema", schema)),
+	)
+}
+
+func patchBody() ast.Expr {
+	return ast.NewStruct(
+		"required", ast.NewBool(true),
+		"content", ast.NewStruct(
+			"application/merge-patch+json", ast.NewStruct("schema", ast.NewStruct("type", ast.NewString("object"))),
+			"application/json-patch+json", ast.NewStruct("schema", ast.NewStruct(
+				"type", ast.NewString("array"),
+				"items", ast.NewStruct("type", ast.NewString("object")),
+			)),
+		),
+	)
+}
+
+func schemaRef(name string) ast.Expr {
+	return ast.NewStruct("$ref", ast.NewString("#/components/schemas/"+name))
+}
+
+func paramRef(name string) ast.Expr {
+	return ast.NewStruct("$ref", ast.NewString("#/components/parameters/"+name))
+}
+
+func stringSchema() ast.Expr {
+	return ast.NewStruct("type", ast.NewString("string"))
+}
@@ -0,0 +1,38 @@
+package codegen
+
+import (
+	"testing"
+)
+
+func TestOpenAPIJenny_NoParams(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/folder_OpenAPIJenny_NoParams",
+	})
+
+	test.RunManyToOneFromModule(
+		"testdata/codegen/schemas/folder",
+		OpenAPIJenny{},
+	)
+}
+
+func TestOpenAPIJenny_Cluster(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/thing_OpenAPIJenny_NoParams",
+	})
+
+	test.RunManyToOneFromModule(
+		"testdata/codegen/customs/thing",
+		OpenAPIJenny{},
+	)
+}
+
+func TestOpenAPIJenny_Group(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/things_OpenAPIJenny_Group",
+	})
+
+	test.RunManyToOneFromDir(
+		"testdata/codegen",
+		OpenAPIJenny{Group: "things.ext.grafana.com"},
+	)
+}
@@ -0,0 +1,129 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/grafana/codejen"
+	"github.com/grafana/kindsys"
+)
+
+// TSRegistryJenny is a [ManyToOne] that produces a TypeScript barrel file,
+// index.gen.ts, re-exporting the types of the current version of every
+// provided kind.
+//
+// The types are those produced by the Types jenny, such as [TSTypesJenny]
+// wrapped by [LatestJenny] or [LatestMajorsOrXJenny], which is run to find
+// the file each kind's types are exported from. Where Types produces a file
+// for each major version, the one in the directory of the kind's current major
+// version is exported.
+//
+// Each kind's types are exported under a namespace named for the kind in lower
+// camel case, such as textPanelCfg for the TextPanelCfg kind, as composable
+// kinds implementing the same schema interface export the same names:
+//
+//	import { textPanelCfg } from './index.gen';
+//	const options: textPanelCfg.Options = textPanelCfg.defaultOptions;
+type TSRegistryJenny struct {
+	// Types is the jenny producing the TypeScript types of each kind. It must
+	// be a [OneToOne] or [OneToMany].
+	Types codejen.Jenny[kindsys.Kind]
+
+	// TypesDir is the slash-separated path of the directory to which the files
+	// produced by Types are written, relative to that of index.gen.ts. It
+	// defaults to the same directory.
+	TypesDir string
+}
+
+var _ codejen.ManyToOne[kindsys.Kind] = &TSRegistryJenny{}
+
+func (j TSRegistryJenny) JennyName() string {
+	return "TSRegistryJenny"
+}
+
+func (j TSRegistryJenny) Generate(kinds ...kindsys.Kind) (*codejen.File, error) {
+	kinds = append([]kindsys.Kind(nil), kinds...)
+	sort.Slice(kinds, func(i, k int) bool {
+		return kinds[i].Name() < kinds[k].Name()
+	})
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "// Types of the current version of each kind, by kind.\n")
+	namespaces := make(map[string]string)
+	for _, k := range kinds {
+		ns := tsNamespace(k.Name())
+		if other, has := namespaces[ns]; has {
+			return nil, fmt.Errorf("kinds %s and %s are both exported as %s", other, k.Name(), ns)
+		}
+		namespaces[ns] = k.Name()
+
+		f, err := j.typesFile(k)
+		if err != nil {
+			return nil, err
+		}
+		if f == nil {
+			continue
+		}
+
+		from := path.Join(j.TypesDir, filepath.ToSlash(strings.TrimSuffix(f.RelativePath, ".ts")))
+		if !strings.HasPrefix(from, "../") {
+			from = "./" + from
+		}
+		fmt.Fprintf(buf, "export * as %s from '%s';\n", ns, from)
+	}
+
+	return codejen.NewFile("index.gen.ts", buf.Bytes(), j), nil
+}
+
+// typesFile returns the file of types for the current version of the kind
+// produced by the Types jenny, or nil if none is produced.
+func (j TSRegistryJenny) typesFile(k kindsys.Kind) (*codejen.File, error) {
+	switch tj := j.Types.(type) {
+	case codejen.OneToOne[kindsys.Kind]:
+		f, err := tj.Generate(k)
+		if err != nil || f == nil || !f.Exists() {
+			return nil, err
+		}
+		return f, nil
+	case codejen.OneToMany[kindsys.Kind]:
+		files, err := tj.Generate(k)
+		if err != nil || len(files) == 0 {
+			return nil, err
+		}
+		if len(files) == 1 {
+			return &files[0], nil
+		}
+		major := fmt.Sprintf("v%d", k.CurrentVersion()[0])
+		for i, f := range files {
+			if path.Base(path.Dir(filepath.ToSlash(f.RelativePath))) == major {
+				return &files[i], nil
+			}
+		}
+		return nil, fmt.Errorf("%s produced no types for %s of kind %s", tj.JennyName(), major, k.Name())
+	case nil:
+		return nil, fmt.Errorf("no jenny to produce types")
+	default:
+		return nil, fmt.Errorf("%s is neither a OneToOne nor a OneToMany jenny", tj.JennyName())
+	}
+}
+
+// tsNamespace returns the name of the namespace under which the types of the
+// kind with the name are exported: the name in lower camel case.
+func tsNamespace(name string) string {
+	name = strings.ReplaceAll(name, "-", "_")
+	// lower the leading run of capitals, leaving the last of a run followed
+	// by a lowercase letter, as in "HTTPCheck" -> "httpCheck"
+	r := []rune(name)
+	for i := range r {
+		if !unicode.IsUpper(r[i]) || (i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1])) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
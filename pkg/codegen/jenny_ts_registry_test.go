@@ -0,0 +1,67 @@
+package codegen
+
+import (
+	"testing"
+)
+
+func TestTSRegistryJenny_LatestJenny(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/folder_TSRegistryJenny_LatestJenny",
+	})
+
+	test.RunManyToOneFromModule(
+		"testdata/codegen/schemas/folder",
+		TSRegistryJenny{Types: LatestJenny("root", TSTypesJenny{ImportMapper: JennyConfig{}.importMapper()})},
+	)
+}
+
+func TestTSRegistryJenny_LatestMajorsOrXJenny(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/folder_TSRegistryJenny_LatestMajorsOrXJenny",
+	})
+
+	test.RunManyToOneFromModule(
+		"testdata/codegen/schemas/folder",
+		TSRegistryJenny{
+			Types:    LatestMajorsOrXJenny("", false, TSResourceJenny{ImportMapper: JennyConfig{}.importMapper()}),
+			TypesDir: "../types",
+		},
+	)
+}
+
+func TestTSRegistryJenny_AllCategories(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/all_TSRegistryJenny_LatestJenny",
+	})
+
+	test.RunManyToOneFromDir(
+		"testdata/codegen",
+		TSRegistryJenny{Types: LatestJenny("", TSResourceJenny{ImportMapper: JennyConfig{}.importMapper()})},
+	)
+}
+
+func TestTSRegistryJenny_SameInterface(t *testing.T) {
+	test := NewGenTest(t, GenTestConfig{
+		OutputDir: "testdata/codegen/output/composables_TSRegistryJenny_LatestJenny",
+	})
+
+	// TextPanelCfg and TimeseriesPanelCfg both export Options and FieldConfig
+	test.RunManyToOneFromDir(
+		"testdata/codegen/composables",
+		TSRegistryJenny{Types: LatestJenny("", TSTypesJenny{ImportMapper: JennyConfig{}.importMapper()})},
+	)
+}
+
+func TestTSNamespace(t *testing.T) {
+	for name, want := range map[string]string{
+		"Folder":       "folder",
+		"TextPanelCfg": "textPanelCfg",
+		"HTTPCheck":    "httpCheck",
+		"API":          "api",
+		"my-kind":      "my_kind",
+	} {
+		if got := tsNamespace(name); got != want {
+			t.Errorf("tsNamespace(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
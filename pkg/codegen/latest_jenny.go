@@ -40,9 +40,10 @@ func (j *latestj) Generate(kind kindsys.Kind) (*codejen.File, error) {
 		IsGroup: comm.LineageIsGroup,
 	}
 
-	f, err := j.inner.Generate(sfg)
+	inner := innerForKind(j.inner, kind)
+	f, err := inner.Generate(sfg)
 	if err != nil {
-		return nil, fmt.Errorf("%s jenny failed on %s schema for %s: %w", j.inner.JennyName(), sfg.Schema.Version(), kind.Props().Common().Name, err)
+		return nil, fmt.Errorf("%s jenny failed on %s schema for %s: %w", inner.JennyName(), sfg.Schema.Version(), kind.Props().Common().Name, err)
 	}
 	if f == nil || !f.Exists() {
 		return nil, nil
@@ -52,3 +53,19 @@ func (j *latestj) Generate(kind kindsys.Kind) (*codejen.File, error) {
 	f.From = append(f.From, j)
 	return f, nil
 }
+
+// kindJenny is implemented by jennies on [SchemaForGen] that are configured by
+// properties of the kind to which the schema belongs.
+type kindJenny interface {
+	// forKind returns the jenny configured for the kind.
+	forKind(k kindsys.Kind) codejen.OneToOne[SchemaForGen]
+}
+
+// innerForKind returns the inner jenny of [LatestJenny] or
+// [LatestMajorsOrXJenny], configured for the kind if it is a kindJenny.
+func innerForKind(inner codejen.OneToOne[SchemaForGen], k kindsys.Kind) codejen.OneToOne[SchemaForGen] {
+	if kj, is := inner.(kindJenny); is {
+		return kj.forKind(k)
+	}
+	return inner
+}
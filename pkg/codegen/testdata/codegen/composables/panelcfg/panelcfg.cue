@@ -0,0 +1,24 @@
+package kind
+
+import "github.com/grafana/kindsys"
+
+kindsys.Composable
+name:            "TextPanelCfg"
+schemaInterface: "PanelCfg"
+lineage: name:   "textpanelcfg"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		Options: {
+			// The mode in which content is rendered.
+			mode: "markdown" | "html" | *"markdown"
+
+			// The content to render.
+			content: string | *""
+		} @cuetsy(kind="interface")
+		FieldConfig: {
+			// Whether to hide the field from the legend.
+			hideFrom?: bool
+		} @cuetsy(kind="interface")
+	}
+}]
@@ -0,0 +1,21 @@
+package kind
+
+import "github.com/grafana/kindsys"
+
+kindsys.Composable
+name:            "TimeseriesPanelCfg"
+schemaInterface: "PanelCfg"
+lineage: name:   "timeseriespanelcfg"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		Options: {
+			// Whether to show the legend.
+			showLegend: bool | *true
+		} @cuetsy(kind="interface")
+		FieldConfig: {
+			// The width of lines, in pixels.
+			lineWidth?: int & >=0
+		} @cuetsy(kind="interface")
+	}
+}]
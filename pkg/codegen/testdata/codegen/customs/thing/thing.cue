@@ -0,0 +1,20 @@
+package kind
+
+import "github.com/grafana/kindsys"
+
+kindsys.Custom
+name:        "Thing"
+group:       "things"
+description: "A thing, for testing custom kinds."
+crd: scope:  "Cluster"
+lineage: schemas: [{
+	version: [0, 0]
+	schema: {
+		spec: {
+			// The thing's name.
+			name: string
+			// The ID of the thing's owner.
+			ownerID: string
+		}
+	}
+}]
@@ -0,0 +1,81 @@
+package kind
+
+import "github.com/grafana/kindsys"
+
+kindsys.Composable
+name:            "TestDataDataQuery"
+schemaInterface: "DataQuery"
+maturity:        "stable"
+lineage: name:   "testdatadataquery"
+lineage: schemas: [
+	{
+		version: [0, 0]
+		schema: {
+			refId: string
+
+			// The scenario to run.
+			scenarioId?: string
+		}
+	},
+	{
+		version: [1, 0]
+		schema: {
+			refId: string
+
+			// The scenario to run.
+			scenarioId: "random_walk" | "csv_content" | *"random_walk"
+
+			// Number of series to generate.
+			seriesCount?: int & >0
+		}
+	},
+	{
+		version: [1, 1]
+		schema: {
+			refId: string
+
+			// The scenario to run.
+			scenarioId: "random_walk" | "csv_content" | *"random_walk"
+
+			// Number of series to generate.
+			seriesCount?: int & >0
+
+			// Content of the CSV, for the csv_content scenario.
+			csvContent?: string
+		}
+	},
+]
+lineage: lenses: [
+	{
+		to: [0, 0]
+		from: [1, 0]
+		input: _
+		result: {
+			refId:      input.refId
+			scenarioId: input.scenarioId
+		}
+	},
+	{
+		to: [1, 0]
+		from: [0, 0]
+		input: _
+		result: {
+			refId: input.refId
+			if input.scenarioId != _|_ {
+				scenarioId: input.scenarioId
+			}
+		}
+	},
+	{
+		to: [1, 0]
+		from: [1, 1]
+		input: _
+		result: {
+			refId:      input.refId
+			scenarioId: input.scenarioId
+			if input.seriesCount != _|_ {
+				seriesCount: input.seriesCount
+			}
+		}
+	},
+]
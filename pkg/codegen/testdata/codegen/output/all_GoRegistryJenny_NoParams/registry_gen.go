@@ -0,0 +1,69 @@
+package kinds
+
+import (
+	"embed"
+
+	"github.com/grafana/kindsys"
+)
+
+// cueFS holds the CUE files of each kind definition, in a directory beneath _cue
+// named for the kind's machine name.
+//
+//go:embed _cue
+var cueFS embed.FS
+
+// Registry provides the kinds from which it was generated, loaded and bound
+// from their embedded CUE definitions.
+type Registry struct {
+	all []kindsys.Kind
+}
+
+// New loads and binds every kind in the registry. Each kind is bound in its own
+// thema.Runtime.
+func New() (*Registry, error) {
+	r := new(Registry)
+	for _, dir := range []string{
+		"_cue/folder",
+		"_cue/testdatadataquery",
+		"_cue/textpanelcfg",
+		"_cue/thing",
+		"_cue/timeseriespanelcfg",
+	} {
+		k, err := kindsys.LoadKind(cueFS, dir)
+		if err != nil {
+			return nil, err
+		}
+		r.all = append(r.all, k)
+	}
+	return r, nil
+}
+
+// All returns every kind in the registry, ordered by name.
+func (r *Registry) All() []kindsys.Kind {
+	return append([]kindsys.Kind(nil), r.all...)
+}
+
+// Folder returns the Folder core kind.
+func (r *Registry) Folder() kindsys.Core {
+	return r.all[0].(kindsys.Core)
+}
+
+// TestDataDataQuery returns the TestDataDataQuery composable kind.
+func (r *Registry) TestDataDataQuery() kindsys.Composable {
+	return r.all[1].(kindsys.Composable)
+}
+
+// TextPanelCfg returns the TextPanelCfg composable kind.
+func (r *Registry) TextPanelCfg() kindsys.Composable {
+	return r.all[2].(kindsys.Composable)
+}
+
+// Thing returns the Thing custom kind.
+func (r *Registry) Thing() kindsys.Custom {
+	return r.all[3].(kindsys.Custom)
+}
+
+// TimeseriesPanelCfg returns the TimeseriesPanelCfg composable kind.
+func (r *Registry) TimeseriesPanelCfg() kindsys.Composable {
+	return r.all[4].(kindsys.Composable)
+}
@@ -0,0 +1,6 @@
+// Types of the current version of each kind, by kind.
+export * as folder from './folder/folder_types.gen';
+export * as testDataDataQuery from './testdatadataquery/testdatadataquery_types.gen';
+export * as textPanelCfg from './textpanelcfg/textpanelcfg_types.gen';
+export * as thing from './thing/thing_types.gen';
+export * as timeseriesPanelCfg from './timeseriespanelcfg/timeseriespanelcfg_types.gen';
@@ -0,0 +1,4 @@
+// Types of the current version of each kind, by kind.
+export * as testDataDataQuery from './testdatadataquery/testdatadataquery_types.gen';
+export * as textPanelCfg from './textpanelcfg/textpanelcfg_types.gen';
+export * as timeseriesPanelCfg from './timeseriespanelcfg/timeseriespanelcfg_types.gen';
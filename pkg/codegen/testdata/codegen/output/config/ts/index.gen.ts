@@ -0,0 +1,11 @@
+// Code generated - EDITING IS FUTILE. DO NOT EDIT.
+//
+// Generated by:
+//     pkg/codegen/config_test.go
+// Using jennies:
+//     TSRegistryJenny
+//
+// Run 'make gen-cue' from repository root to regenerate.
+
+// Types of the current version of each kind, by kind.
+export * as folder from './folder/folder_types.gen';
@@ -0,0 +1,9 @@
+package testdatadataquery
+
+// TestDataDataQuery defines model for TestDataDataQuery.
+type TestDataDataQuery struct {
+	RefId string `json:"refId"`
+
+	// The scenario to run.
+	ScenarioId *string `json:"scenarioId,omitempty"`
+}
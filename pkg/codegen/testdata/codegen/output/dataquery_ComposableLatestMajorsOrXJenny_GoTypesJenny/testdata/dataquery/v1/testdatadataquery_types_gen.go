@@ -0,0 +1,23 @@
+package testdatadataquery
+
+// Defines values for ScenarioId.
+const (
+	ScenarioIdCsvContent ScenarioId = "csv_content"
+	ScenarioIdRandomWalk ScenarioId = "random_walk"
+)
+
+// TestDataDataQuery defines model for TestDataDataQuery.
+type TestDataDataQuery struct {
+	// Content of the CSV, for the csv_content scenario.
+	CsvContent *string `json:"csvContent,omitempty"`
+	RefId      string  `json:"refId"`
+
+	// The scenario to run.
+	ScenarioId ScenarioId `json:"scenarioId"`
+
+	// Number of series to generate.
+	SeriesCount *int `json:"seriesCount,omitempty"`
+}
+
+// The scenario to run.
+type ScenarioId string
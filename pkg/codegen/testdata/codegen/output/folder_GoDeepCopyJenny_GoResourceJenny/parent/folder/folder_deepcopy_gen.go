@@ -0,0 +1,191 @@
+package folder
+
+import (
+	"time"
+
+	"github.com/grafana/kindsys"
+)
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *OperatorState) DeepCopyInto(out *OperatorState) {
+	*out = *in
+	if in.DescriptiveState != nil {
+		out.DescriptiveState = new(string)
+		*out.DescriptiveState = *in.DescriptiveState
+	}
+	if in.Details != nil {
+		out.Details = make(map[string]any, len(in.Details))
+		for key1, val2 := range in.Details {
+			out.Details[key1] = kindsys.DeepCopyValue(val2)
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *OperatorState) DeepCopy() *OperatorState {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *KubeObjectMetadata) DeepCopyInto(out *KubeObjectMetadata) {
+	*out = *in
+	if in.DeletionTimestamp != nil {
+		out.DeletionTimestamp = new(time.Time)
+		*out.DeletionTimestamp = *in.DeletionTimestamp
+	}
+	if in.Finalizers != nil {
+		out.Finalizers = make([]string, len(in.Finalizers))
+		copy(out.Finalizers, in.Finalizers)
+	}
+	if in.Labels != nil {
+		out.Labels = make(map[string]string, len(in.Labels))
+		for key2, val3 := range in.Labels {
+			out.Labels[key2] = val3
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *KubeObjectMetadata) DeepCopy() *KubeObjectMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeObjectMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *Metadata) DeepCopyInto(out *Metadata) {
+	*out = *in
+	if in.DeletionTimestamp != nil {
+		out.DeletionTimestamp = new(time.Time)
+		*out.DeletionTimestamp = *in.DeletionTimestamp
+	}
+	if in.ExtraFields != nil {
+		out.ExtraFields = make(map[string]any, len(in.ExtraFields))
+		for key1, val2 := range in.ExtraFields {
+			out.ExtraFields[key1] = kindsys.DeepCopyValue(val2)
+		}
+	}
+	if in.Finalizers != nil {
+		out.Finalizers = make([]string, len(in.Finalizers))
+		copy(out.Finalizers, in.Finalizers)
+	}
+	if in.Labels != nil {
+		out.Labels = make(map[string]string, len(in.Labels))
+		for key4, val5 := range in.Labels {
+			out.Labels[key4] = val5
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *Metadata) DeepCopy() *Metadata {
+	if in == nil {
+		return nil
+	}
+	out := new(Metadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *Spec) DeepCopyInto(out *Spec) {
+	*out = *in
+	if in.Description != nil {
+		out.Description = new(string)
+		*out.Description = *in.Description
+	}
+	if in.Parent != nil {
+		out.Parent = new(string)
+		*out.Parent = *in.Parent
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *Spec) DeepCopy() *Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *Status) DeepCopyInto(out *Status) {
+	*out = *in
+	if in.AdditionalFields != nil {
+		out.AdditionalFields = make(map[string]any, len(in.AdditionalFields))
+		for key1, val2 := range in.AdditionalFields {
+			out.AdditionalFields[key1] = kindsys.DeepCopyValue(val2)
+		}
+	}
+	if in.OperatorStates != nil {
+		out.OperatorStates = make(map[string]StatusOperatorState, len(in.OperatorStates))
+		for key3, val4 := range in.OperatorStates {
+			cp5 := val4
+			val4.DeepCopyInto(&cp5)
+			out.OperatorStates[key3] = cp5
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *Status) DeepCopy() *Status {
+	if in == nil {
+		return nil
+	}
+	out := new(Status)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *StatusOperatorState) DeepCopyInto(out *StatusOperatorState) {
+	*out = *in
+	if in.DescriptiveState != nil {
+		out.DescriptiveState = new(string)
+		*out.DescriptiveState = *in.DescriptiveState
+	}
+	if in.Details != nil {
+		out.Details = make(map[string]any, len(in.Details))
+		for key1, val2 := range in.Details {
+			out.Details[key1] = kindsys.DeepCopyValue(val2)
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *StatusOperatorState) DeepCopy() *StatusOperatorState {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusOperatorState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *Folder) DeepCopyInto(out *Folder) {
+	*out = *in
+	in.BasicMetadataObject.DeepCopyInto(&out.BasicMetadataObject)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *Folder) DeepCopy() *Folder {
+	if in == nil {
+		return nil
+	}
+	out := new(Folder)
+	in.DeepCopyInto(out)
+	return out
+}
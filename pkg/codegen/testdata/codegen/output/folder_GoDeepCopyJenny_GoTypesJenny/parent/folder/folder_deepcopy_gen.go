@@ -0,0 +1,118 @@
+package folder
+
+import (
+	"time"
+
+	"github.com/grafana/kindsys"
+)
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *Folder) DeepCopyInto(out *Folder) {
+	*out = *in
+	if in.Metadata.DeletionTimestamp != nil {
+		out.Metadata.DeletionTimestamp = new(time.Time)
+		*out.Metadata.DeletionTimestamp = *in.Metadata.DeletionTimestamp
+	}
+	if in.Metadata.ExtraFields != nil {
+		out.Metadata.ExtraFields = make(map[string]any, len(in.Metadata.ExtraFields))
+		for key1, val2 := range in.Metadata.ExtraFields {
+			out.Metadata.ExtraFields[key1] = kindsys.DeepCopyValue(val2)
+		}
+	}
+	if in.Metadata.Finalizers != nil {
+		out.Metadata.Finalizers = make([]string, len(in.Metadata.Finalizers))
+		copy(out.Metadata.Finalizers, in.Metadata.Finalizers)
+	}
+	if in.Metadata.Labels != nil {
+		out.Metadata.Labels = make(map[string]string, len(in.Metadata.Labels))
+		for key4, val5 := range in.Metadata.Labels {
+			out.Metadata.Labels[key4] = val5
+		}
+	}
+	if in.Spec.Description != nil {
+		out.Spec.Description = new(string)
+		*out.Spec.Description = *in.Spec.Description
+	}
+	if in.Spec.Parent != nil {
+		out.Spec.Parent = new(string)
+		*out.Spec.Parent = *in.Spec.Parent
+	}
+	if in.Status.AdditionalFields != nil {
+		out.Status.AdditionalFields = make(map[string]any, len(in.Status.AdditionalFields))
+		for key6, val7 := range in.Status.AdditionalFields {
+			out.Status.AdditionalFields[key6] = kindsys.DeepCopyValue(val7)
+		}
+	}
+	if in.Status.OperatorStates != nil {
+		out.Status.OperatorStates = make(map[string]StatusOperatorState, len(in.Status.OperatorStates))
+		for key8, val9 := range in.Status.OperatorStates {
+			cp10 := val9
+			val9.DeepCopyInto(&cp10)
+			out.Status.OperatorStates[key8] = cp10
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *Folder) DeepCopy() *Folder {
+	if in == nil {
+		return nil
+	}
+	out := new(Folder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *KubeObjectMetadata) DeepCopyInto(out *KubeObjectMetadata) {
+	*out = *in
+	if in.DeletionTimestamp != nil {
+		out.DeletionTimestamp = new(time.Time)
+		*out.DeletionTimestamp = *in.DeletionTimestamp
+	}
+	if in.Finalizers != nil {
+		out.Finalizers = make([]string, len(in.Finalizers))
+		copy(out.Finalizers, in.Finalizers)
+	}
+	if in.Labels != nil {
+		out.Labels = make(map[string]string, len(in.Labels))
+		for key2, val3 := range in.Labels {
+			out.Labels[key2] = val3
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *KubeObjectMetadata) DeepCopy() *KubeObjectMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeObjectMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *StatusOperatorState) DeepCopyInto(out *StatusOperatorState) {
+	*out = *in
+	if in.DescriptiveState != nil {
+		out.DescriptiveState = new(string)
+		*out.DescriptiveState = *in.DescriptiveState
+	}
+	if in.Details != nil {
+		out.Details = make(map[string]any, len(in.Details))
+		for key1, val2 := range in.Details {
+			out.Details[key1] = kindsys.DeepCopyValue(val2)
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *StatusOperatorState) DeepCopy() *StatusOperatorState {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusOperatorState)
+	in.DeepCopyInto(out)
+	return out
+}
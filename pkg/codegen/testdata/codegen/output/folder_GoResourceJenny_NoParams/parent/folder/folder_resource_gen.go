@@ -0,0 +1,143 @@
+package folder
+
+import (
+	"time"
+
+	"github.com/grafana/kindsys"
+)
+
+// Defines values for OperatorStateState.
+const (
+	OperatorStateStateFailed     OperatorStateState = "failed"
+	OperatorStateStateInProgress OperatorStateState = "in_progress"
+	OperatorStateStateSuccess    OperatorStateState = "success"
+)
+
+// Defines values for StatusOperatorStateState.
+const (
+	StatusOperatorStateStateFailed     StatusOperatorStateState = "failed"
+	StatusOperatorStateStateInProgress StatusOperatorStateState = "in_progress"
+	StatusOperatorStateStateSuccess    StatusOperatorStateState = "success"
+)
+
+// OperatorState defines model for OperatorState.
+type OperatorState struct {
+	// descriptiveState is an optional more descriptive state field which has no requirements on format
+	DescriptiveState *string `json:"descriptiveState,omitempty"`
+
+	// details contains any extra information that is operator-specific
+	Details map[string]any `json:"details,omitempty"`
+
+	// lastEvaluation is the ResourceVersion last evaluated
+	LastEvaluation string `json:"lastEvaluation"`
+
+	// state describes the state of the lastEvaluation.
+	// It is limited to three possible states for machine evaluation.
+	State OperatorStateState `json:"state"`
+}
+
+// OperatorStateState state describes the state of the lastEvaluation.
+// It is limited to three possible states for machine evaluation.
+type OperatorStateState string
+
+// _kubeObjectMetadata is metadata found in a kubernetes object's metadata field.
+// It is not exhaustive and only includes fields which may be relevant to a kind's implementation,
+// As it is also intended to be generic enough to function with any API Server.
+type KubeObjectMetadata struct {
+	CreationTimestamp time.Time         `json:"creationTimestamp"`
+	DeletionTimestamp *time.Time        `json:"deletionTimestamp,omitempty"`
+	Finalizers        []string          `json:"finalizers"`
+	Labels            map[string]string `json:"labels"`
+	ResourceVersion   string            `json:"resourceVersion"`
+	Uid               string            `json:"uid"`
+}
+
+// Metadata defines model for metadata.
+type Metadata struct {
+	CreatedBy         string     `json:"createdBy"`
+	CreationTimestamp time.Time  `json:"creationTimestamp"`
+	DeletionTimestamp *time.Time `json:"deletionTimestamp,omitempty"`
+
+	// extraFields is reserved for any fields that are pulled from the API server metadata but do not have concrete fields in the CUE metadata
+	ExtraFields     map[string]any    `json:"extraFields"`
+	Finalizers      []string          `json:"finalizers"`
+	Labels          map[string]string `json:"labels"`
+	ResourceVersion string            `json:"resourceVersion"`
+	Uid             string            `json:"uid"`
+	UpdateTimestamp time.Time         `json:"updateTimestamp"`
+	UpdatedBy       string            `json:"updatedBy"`
+}
+
+// Spec defines model for spec.
+type Spec struct {
+	// Description of the folder.
+	Description *string `json:"description,omitempty"`
+
+	// UID of the parent folder.
+	Parent *string `json:"parent,omitempty"`
+
+	// Folder title
+	Title string `json:"title"`
+
+	// Unique folder id. (will be k8s name)
+	Uid string `json:"uid"`
+}
+
+// Status defines model for status.
+type Status struct {
+	// additionalFields is reserved for future use
+	AdditionalFields map[string]any `json:"additionalFields,omitempty"`
+
+	// operatorStates is a map of operator ID to operator state evaluations.
+	// Any operator which consumes this kind SHOULD add its state evaluation information to this field.
+	OperatorStates map[string]StatusOperatorState `json:"operatorStates,omitempty"`
+}
+
+// StatusOperatorState defines model for status.#OperatorState.
+type StatusOperatorState struct {
+	// descriptiveState is an optional more descriptive state field which has no requirements on format
+	DescriptiveState *string `json:"descriptiveState,omitempty"`
+
+	// details contains any extra information that is operator-specific
+	Details map[string]any `json:"details,omitempty"`
+
+	// lastEvaluation is the ResourceVersion last evaluated
+	LastEvaluation string `json:"lastEvaluation"`
+
+	// state describes the state of the lastEvaluation.
+	// It is limited to three possible states for machine evaluation.
+	State StatusOperatorStateState `json:"state"`
+}
+
+// StatusOperatorStateState state describes the state of the lastEvaluation.
+// It is limited to three possible states for machine evaluation.
+type StatusOperatorStateState string
+
+// Folder is a kindsys.Resource for schema version 0.1 of the
+// Folder kind. It is the type parameter R of kindsys.BindCoreResource or
+// kindsys.BindCustomResource.
+type Folder struct {
+	kindsys.BasicMetadataObject
+	Spec   Spec   `json:"spec"`
+	Status Status `json:"status"`
+}
+
+var _ kindsys.Resource = &Folder{}
+
+// SpecObject returns the Folder's Spec.
+func (r *Folder) SpecObject() any {
+	return r.Spec
+}
+
+// Subresources returns a map of subresource name to the Folder's
+// corresponding field.
+func (r *Folder) Subresources() map[string]any {
+	return map[string]any{
+		"status": r.Status,
+	}
+}
+
+// Copy returns a copy of the Folder.
+func (r *Folder) Copy() kindsys.Resource {
+	return kindsys.CopyResource(r)
+}
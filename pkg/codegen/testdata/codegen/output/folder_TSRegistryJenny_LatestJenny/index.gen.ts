@@ -0,0 +1,2 @@
+// Types of the current version of each kind, by kind.
+export * as folder from './root/folder/folder_types.gen';
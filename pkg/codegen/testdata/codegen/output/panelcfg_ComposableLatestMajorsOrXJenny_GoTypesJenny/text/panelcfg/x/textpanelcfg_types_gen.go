@@ -0,0 +1,25 @@
+package textpanelcfg
+
+// Defines values for OptionsMode.
+const (
+	OptionsModeHtml     OptionsMode = "html"
+	OptionsModeMarkdown OptionsMode = "markdown"
+)
+
+// FieldConfig defines model for FieldConfig.
+type FieldConfig struct {
+	// Whether to hide the field from the legend.
+	HideFrom *bool `json:"hideFrom,omitempty"`
+}
+
+// Options defines model for Options.
+type Options struct {
+	// The content to render.
+	Content string `json:"content"`
+
+	// The mode in which content is rendered.
+	Mode OptionsMode `json:"mode"`
+}
+
+// The mode in which content is rendered.
+type OptionsMode string
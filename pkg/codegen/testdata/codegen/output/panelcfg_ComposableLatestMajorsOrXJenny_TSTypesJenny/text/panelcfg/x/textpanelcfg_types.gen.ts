@@ -0,0 +1,22 @@
+export interface Options {
+  /**
+   * The content to render.
+   */
+  content: string;
+  /**
+   * The mode in which content is rendered.
+   */
+  mode: ('markdown' | 'html');
+}
+
+export const defaultOptions: Partial<Options> = {
+  content: '',
+  mode: 'markdown',
+};
+
+export interface FieldConfig {
+  /**
+   * Whether to hide the field from the legend.
+   */
+  hideFrom?: boolean;
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/grafana/codejen"
+	"github.com/grafana/kindsys/pkg/compat"
 )
 
 // All the parsed templates in the tmpl subdirectory
@@ -16,10 +17,26 @@ func init() {
 	base := template.New("codegen").Funcs(template.FuncMap{
 		"now":     time.Now,
 		"ToLower": strings.ToLower,
+		"mdcell":  mdcell,
+		"mdcode":  mdcode,
 	})
 	tmpls = template.Must(base.ParseFS(tmplFS, "tmpl/*.tmpl"))
 }
 
+// mdcell escapes s for use in a cell of a Markdown table.
+func mdcell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+// mdcode returns s as a code span in a cell of a Markdown table, or an empty
+// string if s is empty.
+func mdcode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + mdcell(strings.Join(strings.Fields(s), " ")) + "`"
+}
+
 //go:embed tmpl/*.tmpl
 var tmplFS embed.FS
 
@@ -32,4 +49,47 @@ type (
 		From          string
 		Leader        string
 	}
+	tvars_go_resource struct {
+		Name         string
+		Version      string
+		Subresources []tvars_go_field
+	}
+	tvars_go_field struct {
+		Name   string
+		GoName string
+	}
+	tvars_docs struct {
+		Name        string
+		Version     string
+		Description string
+		Maturity    string
+		Category    string
+		Current     string
+		Group       string
+		Scope       string
+		Schemas     []tvars_docs_schema
+		Changelog   []compat.Step
+	}
+	tvars_docs_schema struct {
+		Version string
+		Fields  []tvars_docs_field
+	}
+	tvars_docs_field struct {
+		Path        string
+		Type        string
+		Required    bool
+		Default     string
+		Doc         string
+		Constraints string
+	}
+	tvars_go_registry struct {
+		Package string
+		Kinds   []tvars_go_registry_kind
+	}
+	tvars_go_registry_kind struct {
+		Name        string
+		GoName      string
+		MachineName string
+		Category    string
+	}
 )
@@ -0,0 +1,384 @@
+// Package compat checks the compatibility of consecutive schemas in a kind's
+// lineage.
+//
+// Within a major version, each schema must accept all data that was valid
+// under its predecessor. Thema checks this at bind time, with some known gaps;
+// this package classifies each individual change between schemas so that
+// maturity policies and changelogs can reason about them.
+package compat
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"github.com/grafana/kindsys"
+	"github.com/grafana/thema"
+)
+
+// ErrBreakingChange indicates that a minor version of a schema introduced a
+// change that is not backwards compatible with its predecessor.
+var ErrBreakingChange = errors.New("breaking change in minor version")
+
+// ChangeKind classifies a single change between two schemas.
+type ChangeKind string
+
+const (
+	// FieldAdded is a field present only in the newer schema. It is breaking
+	// if the field is required and has no default.
+	FieldAdded ChangeKind = "field added"
+	// FieldRemoved is a field present only in the older schema. Always breaking.
+	FieldRemoved ChangeKind = "field removed"
+	// MadeRequired is a field that was optional and is now required. Always
+	// breaking.
+	MadeRequired ChangeKind = "made required"
+	// MadeOptional is a field that was required and is now optional.
+	MadeOptional ChangeKind = "made optional"
+	// TypeNarrowed is a field that now accepts a strict subset of the values it
+	// accepted before, such as int becoming uint. Always breaking.
+	TypeNarrowed ChangeKind = "type narrowed"
+	// TypeWidened is a field that now accepts a strict superset of the values
+	// it accepted before.
+	TypeWidened ChangeKind = "type widened"
+	// TypeChanged is a field whose accepted values changed in a way that is
+	// neither a narrowing nor a widening, such as string becoming int. Always
+	// breaking.
+	TypeChanged ChangeKind = "type changed"
+	// EnumValueAdded is a value added to a field's set of allowed values.
+	EnumValueAdded ChangeKind = "enum value added"
+	// EnumValueRemoved is a value removed from a field's set of allowed
+	// values. Always breaking.
+	EnumValueRemoved ChangeKind = "enum value removed"
+	// DefaultChanged is a field whose default value was added, removed or
+	// changed. Breaking unless a default was added where there was none.
+	DefaultChanged ChangeKind = "default changed"
+)
+
+// Change is a single difference between two schemas.
+type Change struct {
+	// Path is the path to the changed field within the schema, with list
+	// elements written as "[]", e.g. "spec.panels[].title".
+	Path string
+	// Kind classifies the change.
+	Kind ChangeKind
+	// Breaking indicates that data valid under the older schema may be
+	// invalid, or mean something different, under the newer one.
+	Breaking bool
+	// Detail describes the change, e.g. `"b" removed from "a" | "b"`.
+	Detail string
+}
+
+func (c Change) String() string {
+	s := fmt.Sprintf("%s: %s", c.Path, c.Kind)
+	if c.Detail != "" {
+		s += " (" + c.Detail + ")"
+	}
+	if c.Breaking {
+		s += " [breaking]"
+	}
+	return s
+}
+
+// Step holds the changes between two consecutive schemas in a lineage.
+type Step struct {
+	From, To thema.SyntacticVersion
+	Changes  []Change
+}
+
+// IsMajor indicates whether the step is to a new major version, across which
+// breaking changes are permitted.
+func (s Step) IsMajor() bool {
+	return s.From[0] != s.To[0]
+}
+
+// Breaking returns the breaking changes in the step.
+func (s Step) Breaking() []Change {
+	var brk []Change
+	for _, c := range s.Changes {
+		if c.Breaking {
+			brk = append(brk, c)
+		}
+	}
+	return brk
+}
+
+// Report holds the changes between each pair of consecutive schemas in a
+// kind's lineage, oldest first.
+type Report struct {
+	// Kind is the name of the checked kind.
+	Kind  string
+	Steps []Step
+}
+
+// Violations returns the steps that introduce breaking changes within a major
+// version.
+func (r Report) Violations() []Step {
+	var vs []Step
+	for _, s := range r.Steps {
+		if !s.IsMajor() && len(s.Breaking()) > 0 {
+			vs = append(vs, s)
+		}
+	}
+	return vs
+}
+
+func (r Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s:", r.Kind)
+	for _, s := range r.Steps {
+		fmt.Fprintf(&b, "\n\t%s -> %s", s.From, s.To)
+		if len(s.Changes) == 0 {
+			b.WriteString(": no changes")
+		}
+		for _, c := range s.Changes {
+			fmt.Fprintf(&b, "\n\t\t%s", c)
+		}
+	}
+	return b.String()
+}
+
+// Check walks each pair of consecutive schemas in the kind's lineage and
+// classifies the changes between them.
+//
+// The full report is always returned. If any minor version introduces a
+// breaking change, the returned error wraps [ErrBreakingChange] and lists
+// the offending changes.
+//
+// Like other operations on a kind's lineage, Check is not safe for use
+// concurrently with other CUE operations in the same [thema.Runtime].
+func Check(k kindsys.Kind) (Report, error) {
+	r := Report{Kind: k.Name()}
+	for sch := k.Lineage().First(); sch != nil && sch.Successor() != nil; sch = sch.Successor() {
+		r.Steps = append(r.Steps, DiffSchemas(sch, sch.Successor()))
+	}
+
+	vs := r.Violations()
+	if len(vs) == 0 {
+		return r, nil
+	}
+	msgs := make([]string, 0, len(vs))
+	for _, s := range vs {
+		for _, c := range s.Breaking() {
+			msgs = append(msgs, fmt.Sprintf("%s -> %s: %s", s.From, s.To, c))
+		}
+	}
+	return r, fmt.Errorf("%w in kind %s:\n%s", ErrBreakingChange, r.Kind, strings.Join(msgs, "\n"))
+}
+
+// DiffSchemas returns the changes between two schemas, from and to. They need
+// not be consecutive, or even in the same lineage.
+func DiffSchemas(from, to thema.Schema) Step {
+	return Step{
+		From:    from.Version(),
+		To:      to.Version(),
+		Changes: Diff(schemaValue(from), schemaValue(to)),
+	}
+}
+
+func schemaValue(sch thema.Schema) cue.Value {
+	return sch.Underlying().LookupPath(cue.ParsePath("schema"))
+}
+
+// Diff returns the changes between two CUE schema values, from and to,
+// sorted by path.
+func Diff(from, to cue.Value) []Change {
+	var d differ
+	d.diff("", from, to)
+	sort.SliceStable(d.changes, func(i, j int) bool {
+		return d.changes[i].Path < d.changes[j].Path
+	})
+	return d.changes
+}
+
+type differ struct {
+	changes []Change
+}
+
+func (d *differ) add(p string, kind ChangeKind, breaking bool, format string, args ...any) {
+	if p == "" {
+		p = "<root>"
+	}
+	d.changes = append(d.changes, Change{
+		Path:     p,
+		Kind:     kind,
+		Breaking: breaking,
+		Detail:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *differ) diff(p string, from, to cue.Value) {
+	fk, tk := from.IncompleteKind(), to.IncompleteKind()
+	switch {
+	case fk == cue.StructKind && tk == cue.StructKind:
+		d.diffStruct(p, from, to)
+		return
+	case fk == cue.ListKind && tk == cue.ListKind:
+		elem := cue.MakePath(cue.AnyIndex)
+		fe, te := from.LookupPath(elem), to.LookupPath(elem)
+		if fe.Exists() && te.Exists() {
+			d.diff(p+"[]", fe, te)
+			d.diffDefault(p, from, to)
+			return
+		}
+	}
+
+	d.diffLeaf(p, from, to)
+	d.diffDefault(p, from, to)
+}
+
+func (d *differ) diffStruct(p string, from, to cue.Value) {
+	ff, tf := fields(from), fields(to)
+	for _, name := range sortedKeys(ff) {
+		fp := joinPath(p, name)
+		ffv := ff[name]
+		tfv, has := tf[name]
+		if !has {
+			d.add(fp, FieldRemoved, true, "")
+			continue
+		}
+
+		switch {
+		case ffv.optional && !tfv.optional:
+			d.add(fp, MadeRequired, true, "")
+		case !ffv.optional && tfv.optional:
+			d.add(fp, MadeOptional, false, "")
+		}
+		d.diff(fp, ffv.v, tfv.v)
+	}
+
+	for _, name := range sortedKeys(tf) {
+		if _, has := ff[name]; has {
+			continue
+		}
+		tfv := tf[name]
+		_, hasDefault := tfv.v.Default()
+		switch {
+		case tfv.optional:
+			d.add(joinPath(p, name), FieldAdded, false, "optional")
+		case hasDefault:
+			d.add(joinPath(p, name), FieldAdded, false, "required, with default")
+		default:
+			d.add(joinPath(p, name), FieldAdded, true, "required")
+		}
+	}
+}
+
+func (d *differ) diffLeaf(p string, from, to cue.Value) {
+	fe, fenum := enumValues(from)
+	te, tenum := enumValues(to)
+	if fenum && tenum {
+		for _, ev := range fe {
+			if !contains(te, ev) {
+				d.add(p, EnumValueRemoved, true, "%s", ev)
+			}
+		}
+		for _, ev := range te {
+			if !contains(fe, ev) {
+				d.add(p, EnumValueAdded, false, "%s", ev)
+			}
+		}
+		return
+	}
+
+	// Raw, as otherwise defaults are used in place of the values they are
+	// defaults for, making e.g. *"a" | "b" indistinguishable from "a".
+	narrowed := from.Subsume(to, cue.Raw()) == nil
+	widened := to.Subsume(from, cue.Raw()) == nil
+	switch {
+	case narrowed && widened:
+	case narrowed:
+		d.add(p, TypeNarrowed, true, "%s to %s", from, to)
+	case widened:
+		d.add(p, TypeWidened, false, "%s to %s", from, to)
+	default:
+		d.add(p, TypeChanged, true, "%s to %s", from, to)
+	}
+}
+
+func (d *differ) diffDefault(p string, from, to cue.Value) {
+	fd, fhas := from.Default()
+	td, thas := to.Default()
+	switch {
+	case fhas && thas:
+		if repr(fd) != repr(td) {
+			d.add(p, DefaultChanged, true, "%s to %s", repr(fd), repr(td))
+		}
+	case fhas:
+		d.add(p, DefaultChanged, true, "%s removed", repr(fd))
+	case thas:
+		d.add(p, DefaultChanged, false, "%s added", repr(td))
+	}
+}
+
+// repr returns the CUE representation of v. Unlike fmt, it quotes concrete
+// strings, as they would be written in a schema.
+func repr(v cue.Value) string {
+	if s, err := v.String(); err == nil {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
+
+type field struct {
+	v        cue.Value
+	optional bool
+}
+
+// fields returns the regular fields of a struct value, including optional
+// ones, keyed by name.
+func fields(v cue.Value) map[string]field {
+	m := make(map[string]field)
+	iter, err := v.Fields(cue.Optional(true))
+	if err != nil {
+		return m
+	}
+	for iter.Next() {
+		m[iter.Selector().String()] = field{v: iter.Value(), optional: iter.IsOptional()}
+	}
+	return m
+}
+
+// enumValues returns the CUE representation of each of the allowed values of
+// v, if v is a disjunction of concrete values such as `*"a" | "b"`.
+func enumValues(v cue.Value) ([]string, bool) {
+	op, args := v.Expr()
+	if op != cue.OrOp {
+		return nil, false
+	}
+	vals := make([]string, 0, len(args))
+	for _, arg := range args {
+		if !arg.IsConcrete() {
+			return nil, false
+		}
+		vals = append(vals, fmt.Sprint(arg))
+	}
+	return vals, true
+}
+
+func joinPath(p, name string) string {
+	if p == "" {
+		return name
+	}
+	return p + "." + name
+}
+
+func sortedKeys(m map[string]field) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(s []string, v string) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package compat
+
+import (
+	"errors"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/grafana/thema"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/kindsys"
+	"github.com/grafana/kindsys/internal/kindtest"
+)
+
+func TestDiff(t *testing.T) {
+	tests := map[string]struct {
+		from, to string
+		want     []Change
+	}{
+		"unchanged": {
+			from: `a: string, b?: int`,
+			to:   `a: string, b?: int`,
+		},
+		"optional field added": {
+			from: `a: string`,
+			to:   `a: string, b?: int`,
+			want: []Change{{Path: "b", Kind: FieldAdded, Detail: "optional"}},
+		},
+		"required field added": {
+			from: `a: string`,
+			to:   `a: string, b: int`,
+			want: []Change{{Path: "b", Kind: FieldAdded, Breaking: true, Detail: "required"}},
+		},
+		"required field with default added": {
+			from: `a: string`,
+			to:   `a: string, b: int | *1`,
+			want: []Change{{Path: "b", Kind: FieldAdded, Detail: "required, with default"}},
+		},
+		"field removed": {
+			from: `a: string, b?: int`,
+			to:   `a: string`,
+			want: []Change{{Path: "b", Kind: FieldRemoved, Breaking: true}},
+		},
+		"optional to required": {
+			from: `a?: string`,
+			to:   `a: string`,
+			want: []Change{{Path: "a", Kind: MadeRequired, Breaking: true}},
+		},
+		"required to optional": {
+			from: `a: string`,
+			to:   `a?: string`,
+			want: []Change{{Path: "a", Kind: MadeOptional}},
+		},
+		"type narrowed": {
+			from: `a: int`,
+			to:   `a: int & >0`,
+			want: []Change{{Path: "a", Kind: TypeNarrowed, Breaking: true, Detail: "int to >0 & int"}},
+		},
+		"type widened": {
+			from: `a: "x" | "y"`,
+			to:   `a: string`,
+			want: []Change{{Path: "a", Kind: TypeWidened, Detail: `"x" | "y" to string`}},
+		},
+		"type changed": {
+			from: `a: string`,
+			to:   `a: int`,
+			want: []Change{{Path: "a", Kind: TypeChanged, Breaking: true, Detail: "string to int"}},
+		},
+		"enum values": {
+			from: `a: "x" | "y"`,
+			to:   `a: "y" | "z"`,
+			want: []Change{
+				{Path: "a", Kind: EnumValueRemoved, Breaking: true, Detail: `"x"`},
+				{Path: "a", Kind: EnumValueAdded, Detail: `"z"`},
+			},
+		},
+		"default changed": {
+			from: `a: *"x" | "y"`,
+			to:   `a: "x" | *"y"`,
+			want: []Change{{Path: "a", Kind: DefaultChanged, Breaking: true, Detail: `"x" to "y"`}},
+		},
+		"default added": {
+			from: `a: int`,
+			to:   `a: int | *3`,
+			want: []Change{{Path: "a", Kind: DefaultChanged, Detail: "3 added"}},
+		},
+		"nested in lists and structs": {
+			from: `spec: items: [...{name: string}]`,
+			to:   `spec: items: [...{name: string, id?: int}]`,
+			want: []Change{{Path: "spec.items[].id", Kind: FieldAdded, Detail: "optional"}},
+		},
+	}
+
+	ctx := cuecontext.New()
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			from, to := ctx.CompileString(tt.from), ctx.CompileString(tt.to)
+			require.NoError(t, from.Err())
+			require.NoError(t, to.Err())
+			require.Equal(t, tt.want, Diff(from, to))
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := map[string]struct {
+		schemas  string
+		breaking bool
+	}{
+		"compatible minor": {
+			schemas: `[{
+	version: [0, 0]
+	schema: spec: title: string
+}, {
+	version: [0, 1]
+	schema: spec: {
+		title:  string
+		color?: string
+	}
+}]`,
+		},
+		"breaking minor": {
+			schemas: `[{
+	version: [0, 0]
+	schema: spec: title: string
+}, {
+	version: [0, 1]
+	schema: spec: title: int
+}]`,
+			breaking: true,
+		},
+		"breaking major": {
+			schemas: `[{
+	version: [0, 0]
+	schema: spec: title: string
+}, {
+	version: [1, 0]
+	schema: spec: title: int
+}]`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			k := bindTestKind(t, tt.schemas)
+			r, err := Check(k)
+			require.Len(t, r.Steps, 1)
+			if !tt.breaking {
+				require.NoError(t, err)
+				require.Empty(t, r.Violations())
+				return
+			}
+			require.True(t, errors.Is(err, ErrBreakingChange), err)
+			require.Len(t, r.Violations(), 1)
+			require.Equal(t, []Change{{
+				Path:     "spec.title",
+				Kind:     TypeChanged,
+				Breaking: true,
+				Detail:   "string to int",
+			}}, r.Violations()[0].Breaking())
+		})
+	}
+}
+
+func bindTestKind(t *testing.T, schemas string) kindsys.Kind {
+	t.Helper()
+	// Thema refuses to bind lineages with breaking minor versions, except for
+	// the cases it misses. Skip that check to exercise ours.
+	return kindtest.BindCore(t, `name:        "TestCompat"
+description: "A core kind for tests."
+lineage: schemas: `+schemas, thema.SkipBuggyChecks())
+}
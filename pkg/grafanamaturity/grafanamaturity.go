@@ -0,0 +1,189 @@
+// Package grafanamaturity collects the @grafanamaturity attributes in kind
+// schemas.
+//
+// @grafanamaturity attributes are structured TODOs, marking schema fields that
+// still need work before their kind can advance in maturity:
+//
+//	title: string @grafanamaturity(NeedsExpertReview, owner="dashboards")
+//
+// This package reports on them, so that schema TODO debt can be tracked across
+// many kinds.
+package grafanamaturity
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/grafana/kindsys"
+)
+
+// AttrName is the name of the attribute collected by this package.
+const AttrName = "grafanamaturity"
+
+// Attribute is a single @grafanamaturity attribute found in a kind's schema.
+type Attribute struct {
+	// Schema is the version of the schema containing the attribute.
+	Schema string `json:"schema"`
+	// Path is the path to the attributed field within the schema, with list
+	// elements written as "[]", e.g. "spec.panels[].title".
+	Path string `json:"path"`
+	// Pos is the position of the attribute in CUE source.
+	Pos string `json:"pos,omitempty"`
+	// Args holds the positional arguments of the attribute.
+	Args []string `json:"args,omitempty"`
+	// Params holds the key=value arguments of the attribute.
+	Params map[string]string `json:"params,omitempty"`
+}
+
+func (a Attribute) String() string {
+	args := append([]string(nil), a.Args...)
+	for _, k := range sortedKeys(a.Params) {
+		args = append(args, fmt.Sprintf("%s=%q", k, a.Params[k]))
+	}
+	return fmt.Sprintf("%s: %s %s @%s(%s)", a.Pos, a.Schema, a.Path, AttrName, strings.Join(args, ", "))
+}
+
+// KindReport holds all the @grafanamaturity attributes in a single kind.
+type KindReport struct {
+	Kind     string           `json:"kind"`
+	Maturity kindsys.Maturity `json:"maturity"`
+	// Latest is the version of the kind's latest schema.
+	Latest string `json:"latest"`
+	// Attributes holds every attribute from every schema in the kind's
+	// lineage, oldest schema first, then in field order.
+	Attributes []Attribute `json:"attributes"`
+}
+
+// Summary summarizes the @grafanamaturity attributes in a single kind.
+type Summary struct {
+	Kind     string           `json:"kind"`
+	Maturity kindsys.Maturity `json:"maturity"`
+	// Total is the number of attributes across all schemas.
+	Total int `json:"total"`
+	// Latest is the number of attributes in the latest schema, which is
+	// the debt remaining to be paid.
+	Latest int `json:"latest"`
+	// ByArg counts the attributes in the latest schema by each of their
+	// positional arguments, e.g. NeedsExpertReview.
+	ByArg map[string]int `json:"byArg,omitempty"`
+}
+
+// Summary summarizes the report.
+func (r KindReport) Summary() Summary {
+	s := Summary{
+		Kind:     r.Kind,
+		Maturity: r.Maturity,
+		Total:    len(r.Attributes),
+	}
+	for _, a := range r.Attributes {
+		if a.Schema != r.Latest {
+			continue
+		}
+		s.Latest++
+		for _, arg := range a.Args {
+			if s.ByArg == nil {
+				s.ByArg = make(map[string]int)
+			}
+			s.ByArg[arg]++
+		}
+	}
+	return s
+}
+
+// Collect walks every schema in the kind's lineage, collecting each
+// @grafanamaturity attribute.
+//
+// Attributes are parsed by the parser registered with kindsys, which has
+// already validated them when the kind was bound. Any that are nevertheless
+// malformed are omitted.
+func Collect(k kindsys.Kind) KindReport {
+	r := KindReport{
+		Kind:       k.Name(),
+		Maturity:   k.Maturity(),
+		Latest:     k.Lineage().Latest().Version().String(),
+		Attributes: []Attribute{},
+	}
+	for sch := k.Lineage().First(); sch != nil; sch = sch.Successor() {
+		sa, _ := kindsys.ParseSchemaAttributes(sch) //nolint:errcheck
+		for _, attr := range sa.Named(AttrName) {
+			ga := attr.Value.(kindsys.GrafanaMaturityAttribute)
+			r.Attributes = append(r.Attributes, Attribute{
+				Schema: sa.Schema.String(),
+				Path:   attr.Path,
+				Pos:    trimPos(attr.Pos.String()),
+				Args:   ga.Args,
+				Params: ga.Params,
+			})
+		}
+	}
+	return r
+}
+
+// Report holds the @grafanamaturity attributes in many kinds.
+type Report struct {
+	// Kinds holds one report per kind, sorted by kind name.
+	Kinds []KindReport `json:"kinds"`
+	// Summaries holds one summary per kind, in the same order as Kinds.
+	Summaries []Summary `json:"summaries"`
+}
+
+// NewReport collects the @grafanamaturity attributes in each of the provided
+// kinds.
+func NewReport(kinds ...kindsys.Kind) Report {
+	r := Report{
+		Kinds:     make([]KindReport, 0, len(kinds)),
+		Summaries: make([]Summary, 0, len(kinds)),
+	}
+	for _, k := range kinds {
+		r.Kinds = append(r.Kinds, Collect(k))
+	}
+	sort.Slice(r.Kinds, func(i, j int) bool {
+		return r.Kinds[i].Kind < r.Kinds[j].Kind
+	})
+	for _, kr := range r.Kinds {
+		r.Summaries = append(r.Summaries, kr.Summary())
+	}
+	return r
+}
+
+// WriteJSON writes the full report to w as indented JSON.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteSummary writes a human-readable table summarizing each kind in the
+// report to w.
+func (r Report) WriteSummary(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tMATURITY\tLATEST\tTOTAL\tBY ARG")
+	for _, s := range r.Summaries {
+		byArg := make([]string, 0, len(s.ByArg))
+		for _, arg := range sortedKeys(s.ByArg) {
+			byArg = append(byArg, fmt.Sprintf("%s=%d", arg, s.ByArg[arg]))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\n", s.Kind, s.Maturity, s.Latest, s.Total, strings.Join(byArg, ","))
+	}
+	return tw.Flush()
+}
+
+// trimPos strips the module path prefix from positions in kind definitions
+// loaded with the kindsys framework overlaid, which are otherwise rooted at
+// /github.com/grafana/kindsys.
+func trimPos(pos string) string {
+	return strings.TrimPrefix(pos, "/github.com/grafana/kindsys/")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
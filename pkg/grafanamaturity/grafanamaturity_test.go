@@ -0,0 +1,98 @@
+package grafanamaturity
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/kindsys"
+	"github.com/grafana/kindsys/internal/kindtest"
+)
+
+func TestCollect(t *testing.T) {
+	k := bindTestKind(t, "TestMaturity", `[{
+	version: [0, 0]
+	schema: {
+		spec: {
+			title: string @grafanamaturity(NeedsExpertReview)
+		}
+	}
+}, {
+	version: [0, 1]
+	schema: {
+		spec: {
+			title: string @grafanamaturity(NeedsExpertReview)
+			panels?: [...{
+				type: string @grafanamaturity(NeedsExpertReview, ToMetadata, owner="dashboards")
+			}]
+		}
+	}
+}]`)
+
+	r := Collect(k)
+	require.Equal(t, "TestMaturity", r.Kind)
+	require.Equal(t, "0.1", r.Latest)
+	require.Equal(t, []Attribute{{
+		Schema: "0.0",
+		Path:   "spec.title",
+		Pos:    "kind/kind.cue:12:18",
+		Args:   []string{"NeedsExpertReview"},
+	}, {
+		Schema: "0.1",
+		Path:   "spec.title",
+		Pos:    "kind/kind.cue:19:18",
+		Args:   []string{"NeedsExpertReview"},
+	}, {
+		Schema: "0.1",
+		Path:   "spec.panels[].type",
+		Pos:    "kind/kind.cue:21:18",
+		Args:   []string{"NeedsExpertReview", "ToMetadata"},
+		Params: map[string]string{"owner": "dashboards"},
+	}}, r.Attributes)
+
+	require.Equal(t, Summary{
+		Kind:     "TestMaturity",
+		Maturity: kindsys.MaturityMerged,
+		Total:    3,
+		Latest:   2,
+		ByArg:    map[string]int{"NeedsExpertReview": 2, "ToMetadata": 1},
+	}, r.Summary())
+}
+
+func TestReport(t *testing.T) {
+	clean := bindTestKind(t, "Clean", `[{
+	version: [0, 0]
+	schema: spec: title: string
+}]`)
+	wip := bindTestKind(t, "Wip", `[{
+	version: [0, 0]
+	schema: spec: title: string @grafanamaturity(NeedsExpertReview)
+}]`)
+
+	r := NewReport(wip, clean)
+	require.Len(t, r.Kinds, 2)
+	require.Equal(t, "Clean", r.Kinds[0].Kind)
+	require.Equal(t, "Wip", r.Summaries[1].Kind)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteJSON(&buf))
+	var decoded Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, r, decoded)
+
+	buf.Reset()
+	require.NoError(t, r.WriteSummary(&buf))
+	require.Equal(t, `KIND   MATURITY  LATEST  TOTAL  BY ARG
+Clean  merged    0       0      
+Wip    merged    1       1      NeedsExpertReview=1
+`, buf.String())
+}
+
+func bindTestKind(t *testing.T, name, schemas string) kindsys.Kind {
+	t.Helper()
+	return kindtest.BindCore(t, `name:        "`+name+`"
+description: "A core kind for tests."
+lineage: schemas: `+schemas)
+}
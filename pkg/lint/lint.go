@@ -0,0 +1,154 @@
+// Package lint checks kinds for naming and schema hygiene.
+//
+// Unlike the maturity rules in pkg/policy, lint rules are conventions rather
+// than guarantees. Each [Rule] may be individually enabled or disabled on a
+// [Linter], and reports [Finding]s positioned in the kind's CUE source.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"cuelang.org/go/cue/token"
+	"github.com/grafana/kindsys"
+)
+
+// Rule is a single lint check on kinds.
+type Rule interface {
+	// Name uniquely identifies the rule, e.g. "lowercase-id".
+	Name() string
+
+	// Description briefly describes what the rule checks.
+	Description() string
+
+	// Lint checks the provided kind, returning any findings. The Rule and Kind
+	// fields of returned Findings need not be set; the [Linter] sets them.
+	Lint(k kindsys.Kind) []Finding
+}
+
+// Finding is a single problem reported by a [Rule].
+type Finding struct {
+	// Rule is the name of the reporting rule.
+	Rule string
+	// Kind is the name of the kind in which the problem was found.
+	Kind string
+	// Schema is the version of the schema in which the problem was found.
+	Schema string
+	// Path is the path to the offending field within the schema, with list
+	// elements written as "[]", e.g. "spec.panels[].title".
+	Path string
+	// Pos is the position of the offending field in CUE source, if known.
+	Pos token.Pos
+	// Message describes the problem.
+	Message string
+}
+
+func (f Finding) String() string {
+	var b strings.Builder
+	if f.Pos.IsValid() {
+		b.WriteString(f.Pos.String() + ": ")
+	}
+	fmt.Fprintf(&b, "%s", f.Kind)
+	if f.Schema != "" {
+		fmt.Fprintf(&b, "@%s", f.Schema)
+	}
+	if f.Path != "" {
+		fmt.Fprintf(&b, " %s", f.Path)
+	}
+	fmt.Fprintf(&b, ": %s (%s)", f.Message, f.Rule)
+	return b.String()
+}
+
+// Findings is a list of [Finding]s, usable as an error.
+type Findings []Finding
+
+func (fs Findings) Error() string {
+	msgs := make([]string, 0, len(fs))
+	for _, f := range fs {
+		msgs = append(msgs, f.String())
+	}
+	return fmt.Sprintf("%d lint finding(s):\n%s", len(fs), strings.Join(msgs, "\n"))
+}
+
+// Linter runs a set of [Rule]s over kinds.
+type Linter struct {
+	rules    []Rule
+	disabled map[string]bool
+}
+
+// New creates a Linter with the provided rules, all enabled. If no rules are
+// provided, the [DefaultRules] are used.
+func New(rules ...Rule) *Linter {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	return &Linter{
+		rules:    rules,
+		disabled: make(map[string]bool),
+	}
+}
+
+// Rules returns all the Linter's rules, enabled or not.
+func (l *Linter) Rules() []Rule {
+	return l.rules
+}
+
+// Disable disables the named rules. It returns an error if any name does not
+// match one of the Linter's rules.
+func (l *Linter) Disable(names ...string) error {
+	return l.toggle(true, names)
+}
+
+// Enable re-enables the named rules. It returns an error if any name does not
+// match one of the Linter's rules.
+func (l *Linter) Enable(names ...string) error {
+	return l.toggle(false, names)
+}
+
+func (l *Linter) toggle(disabled bool, names []string) error {
+	for _, name := range names {
+		if !l.has(name) {
+			return fmt.Errorf("unknown lint rule %q", name)
+		}
+		l.disabled[name] = disabled
+	}
+	return nil
+}
+
+func (l *Linter) has(name string) bool {
+	for _, r := range l.rules {
+		if r.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Enabled indicates whether the named rule is enabled.
+func (l *Linter) Enabled(name string) bool {
+	return l.has(name) && !l.disabled[name]
+}
+
+// Lint runs each enabled rule over the kind, returning all findings sorted by
+// position.
+func (l *Linter) Lint(k kindsys.Kind) Findings {
+	var fs Findings
+	for _, r := range l.rules {
+		if l.disabled[r.Name()] {
+			continue
+		}
+		for _, f := range r.Lint(k) {
+			f.Rule, f.Kind = r.Name(), k.Name()
+			fs = append(fs, f)
+		}
+	}
+	sort.SliceStable(fs, func(i, j int) bool {
+		pi, pj := fs[i].Pos.Position(), fs[j].Pos.Position()
+		if pi.Filename != pj.Filename {
+			return pi.Filename < pj.Filename
+		}
+		return pi.Offset < pj.Offset
+	})
+	return fs
+}
@@ -0,0 +1,85 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/kindsys"
+	"github.com/grafana/kindsys/internal/kindtest"
+)
+
+const messySchema = `{
+		#Kind: "fooBar" | "Baz_Qux" @cuetsy(kind="enum")
+		spec: {
+			// the id
+			Id: string
+			// the folder
+			folderUID: string
+			// snakes
+			snake_case: int
+			// anything goes
+			blob: _
+			// kind
+			kind: #Kind
+			// options
+			options: {
+				// a
+				a: string
+				...
+			}
+			undocumented: bool
+		}
+	}`
+
+func TestDefaultRules(t *testing.T) {
+	k := bindTestKind(t, kindsys.MaturityStable, messySchema)
+	fs := New().Lint(k)
+
+	type short struct{ rule, path, msg string }
+	var got []short
+	for _, f := range fs {
+		require.Equal(t, "TestLint", f.Kind)
+		require.Equal(t, "0.0", f.Schema)
+		require.True(t, f.Pos.IsValid(), f.String())
+		got = append(got, short{f.Rule, f.Path, f.Message})
+	}
+	require.Equal(t, []short{
+		{"enum-camelcase", "#Kind", `enum value "Baz_Qux" is not camelCase`},
+		{"lowercase-id", "spec.Id", `field "Id" should be named "id"`},
+		{"lowercase-id", "spec.folderUID", `field "folderUID" should be named "folderUid"`},
+		{"field-casing", "spec.snake_case", `field "snake_case" is not camelCase`},
+		{"no-top-in-spec", "spec.blob", "field has type _, which accepts any value"},
+		{"closed-structs-at-stable", "spec.options", "open struct not allowed at maturity stable"},
+		{"spec-field-docs", "spec.undocumented", "field has no doc comment"},
+	}, got)
+
+	// Positions point at the offending field
+	require.Equal(t, 15, fs[1].Pos.Line())
+}
+
+func TestToggleRules(t *testing.T) {
+	l := New()
+	require.NoError(t, l.Disable("lowercase-id", "field-casing", "spec-field-docs", "enum-camelcase", "no-top-in-spec"))
+	require.False(t, l.Enabled("lowercase-id"))
+	require.Error(t, l.Disable("nope"))
+
+	// open structs are fine before stable
+	require.Empty(t, l.Lint(bindTestKind(t, kindsys.MaturityExperimental, messySchema)))
+	require.Len(t, l.Lint(bindTestKind(t, kindsys.MaturityStable, messySchema)), 1)
+
+	require.NoError(t, l.Enable("lowercase-id"))
+	require.True(t, l.Enabled("lowercase-id"))
+	require.Len(t, l.Lint(bindTestKind(t, kindsys.MaturityStable, messySchema)), 3)
+}
+
+func bindTestKind(t *testing.T, maturity kindsys.Maturity, schema string) kindsys.Kind {
+	t.Helper()
+	return kindtest.BindCore(t, `name:        "TestLint"
+maturity:    "`+string(maturity)+`"
+description: "A core kind for tests."
+lineage: schemas: [{
+	version: [0, 0]
+	schema: `+schema+`
+}]`)
+}
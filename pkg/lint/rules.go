@@ -0,0 +1,233 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"github.com/grafana/kindsys"
+)
+
+// DefaultRules returns all the lint rules provided by this package.
+func DefaultRules() []Rule {
+	return []Rule{
+		LowercaseID(),
+		SpecFieldDocs(),
+		NoTopInSpec(),
+		ClosedStructsAtStable(),
+		FieldCasing(),
+		EnumCamelCase(),
+	}
+}
+
+// fieldRule is a Rule that checks each field in a kind's latest schema.
+type fieldRule struct {
+	name, desc string
+	// check returns a message for each problem with the field.
+	check func(k kindsys.Kind, f field) []string
+}
+
+// field is a single field visited in a schema.
+type field struct {
+	path string
+	sel  cue.Selector
+	v    cue.Value
+	// inSpec indicates the field is within the kind's spec, or is any field
+	// of a composable kind, which has no spec.
+	inSpec bool
+}
+
+func (r fieldRule) Name() string        { return r.name }
+func (r fieldRule) Description() string { return r.desc }
+
+func (r fieldRule) Lint(k kindsys.Kind) []Finding {
+	sch := k.Lineage().Latest()
+	ver := sch.Version().String()
+	_, composable := k.(kindsys.Composable)
+
+	var fs []Finding
+	// the fields of definitions are linted where they are declared, not at
+	// each reference
+	cfg := kindsys.WalkFieldsConfig{Definitions: true}
+	kindsys.WalkFields(sch.Underlying().LookupPath(cue.ParsePath("schema")), cfg, func(kf kindsys.Field) {
+		f := field{
+			path:   kf.Path,
+			sel:    kf.Selector,
+			v:      kf.Value,
+			inSpec: composable || strings.HasPrefix(kf.Path, "spec."),
+		}
+		for _, msg := range r.check(k, f) {
+			fs = append(fs, Finding{
+				Schema:  ver,
+				Path:    f.path,
+				Pos:     f.v.Pos(),
+				Message: msg,
+			})
+		}
+	})
+	return fs
+}
+
+var idNames = map[string]string{
+	"Id":  "id",
+	"ID":  "id",
+	"Uid": "uid",
+	"UID": "uid",
+}
+
+// idFix returns the preferred spelling of a field name that misspells an
+// identifier, like "Id" or "folderUID".
+func idFix(name string) (string, bool) {
+	if fix, has := idNames[name]; has {
+		return fix, true
+	}
+	for _, suffix := range []string{"UID", "ID"} {
+		if prefix := strings.TrimSuffix(name, suffix); prefix != name && camelCase.MatchString(prefix) {
+			return prefix + suffix[:1] + strings.ToLower(suffix[1:]), true
+		}
+	}
+	return "", false
+}
+
+// LowercaseID returns a rule reporting fields named like Id, Uid or folderUID,
+// rather than id, uid or folderUid.
+func LowercaseID() Rule {
+	return fieldRule{
+		name: "lowercase-id",
+		desc: "identifier fields are named id, uid, or with an Id or Uid suffix",
+		check: func(k kindsys.Kind, f field) []string {
+			if f.sel.IsDefinition() {
+				return nil
+			}
+			if fix, is := idFix(f.sel.Unquoted()); is {
+				return []string{fmt.Sprintf("field %q should be named %q", f.sel.Unquoted(), fix)}
+			}
+			return nil
+		},
+	}
+}
+
+// SpecFieldDocs returns a rule reporting spec fields without doc comments.
+func SpecFieldDocs() Rule {
+	return fieldRule{
+		name: "spec-field-docs",
+		desc: "fields in spec have doc comments",
+		check: func(k kindsys.Kind, f field) []string {
+			if f.inSpec && len(f.v.Doc()) == 0 {
+				return []string{"field has no doc comment"}
+			}
+			return nil
+		},
+	}
+}
+
+// NoTopInSpec returns a rule reporting spec fields of type _ (top), which
+// accept any value, and so are untyped in generated code.
+func NoTopInSpec() Rule {
+	return fieldRule{
+		name: "no-top-in-spec",
+		desc: "fields in spec are not of type _ (top)",
+		check: func(k kindsys.Kind, f field) []string {
+			if f.inSpec && f.v.IncompleteKind() == cue.TopKind {
+				return []string{"field has type _, which accepts any value"}
+			}
+			return nil
+		},
+	}
+}
+
+// ClosedStructsAtStable returns a rule reporting open structs, those
+// containing "...", in kinds at stable maturity or above. Open structs accept
+// arbitrary fields, which cannot be evolved compatibly.
+func ClosedStructsAtStable() Rule {
+	return fieldRule{
+		name: "closed-structs-at-stable",
+		desc: "structs are closed in kinds at stable maturity or above",
+		check: func(k kindsys.Kind, f field) []string {
+			if k.Maturity().Less(kindsys.MaturityStable) {
+				return nil
+			}
+			if isOpenStruct(f.v) {
+				return []string{fmt.Sprintf("open struct not allowed at maturity %s", k.Maturity())}
+			}
+			return nil
+		},
+	}
+}
+
+// isOpenStruct indicates whether the struct literal declaring the field value
+// v contains an ellipsis.
+func isOpenStruct(v cue.Value) bool {
+	f, ok := v.Source().(*ast.Field)
+	if !ok {
+		return false
+	}
+	lit, ok := f.Value.(*ast.StructLit)
+	if !ok {
+		return false
+	}
+	for _, elt := range lit.Elts {
+		if _, is := elt.(*ast.Ellipsis); is {
+			return true
+		}
+	}
+	return false
+}
+
+var camelCase = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+// FieldCasing returns a rule reporting field names that are not camelCase,
+// such as snake_case or PascalCase. Definitions are exempt.
+func FieldCasing() Rule {
+	return fieldRule{
+		name: "field-casing",
+		desc: "field names are camelCase",
+		check: func(k kindsys.Kind, f field) []string {
+			if f.sel.IsDefinition() {
+				return nil
+			}
+			name := f.sel.Unquoted()
+			if camelCase.MatchString(name) {
+				return nil
+			}
+			if _, is := idFix(name); is {
+				// reported by lowercase-id
+				return nil
+			}
+			return []string{fmt.Sprintf("field %q is not camelCase", name)}
+		},
+	}
+}
+
+// EnumCamelCase returns a rule reporting string enum values that are not
+// camelCase.
+func EnumCamelCase() Rule {
+	return fieldRule{
+		name: "enum-camelcase",
+		desc: "string enum values are camelCase",
+		check: func(k kindsys.Kind, f field) []string {
+			if kindsys.IsReference(f.v) {
+				// linted where the referenced value is declared
+				return nil
+			}
+			op, args := f.v.Expr()
+			if op != cue.OrOp {
+				return nil
+			}
+			var msgs []string
+			for _, arg := range args {
+				s, err := arg.String()
+				if err != nil || !arg.IsConcrete() {
+					// not a string enum
+					return nil
+				}
+				if !camelCase.MatchString(s) {
+					msgs = append(msgs, fmt.Sprintf("enum value %q is not camelCase", s))
+				}
+			}
+			return msgs
+		},
+	}
+}
@@ -0,0 +1,110 @@
+// Package policy enforces the guarantees promised by each kind maturity.
+//
+// Each [Rule] applies to kinds at or above a particular maturity. An [Engine]
+// evaluates a set of rules against bound kinds, reporting any violations.
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grafana/kindsys"
+)
+
+// Rule is a single policy check on kinds.
+type Rule interface {
+	// Name uniquely identifies the rule, e.g. "no-grafanamaturity".
+	Name() string
+
+	// Maturity is the lowest kind maturity at which the rule applies.
+	Maturity() kindsys.Maturity
+
+	// Check evaluates the rule against the provided kind, returning any
+	// violations. The Rule and Kind fields of returned Violations need not be
+	// set; the [Engine] sets them.
+	Check(k kindsys.Kind) []Violation
+}
+
+// Violation describes a single failure of a kind to satisfy a [Rule].
+type Violation struct {
+	// Rule is the name of the violated rule.
+	Rule string
+	// Kind is the name of the violating kind.
+	Kind string
+	// Path is the path to the violating field within the kind's schema, if
+	// the violation is specific to one.
+	Path string
+	// Message describes the violation.
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return fmt.Sprintf("%s: %s (%s)", v.Kind, v.Message, v.Rule)
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", v.Kind, v.Path, v.Message, v.Rule)
+}
+
+// Violations is a list of [Violation]s, usable as an error.
+type Violations []Violation
+
+func (vs Violations) Error() string {
+	msgs := make([]string, 0, len(vs))
+	for _, v := range vs {
+		msgs = append(msgs, v.String())
+	}
+	return fmt.Sprintf("%d policy violation(s):\n%s", len(vs), strings.Join(msgs, "\n"))
+}
+
+// Engine evaluates a set of [Rule]s against kinds.
+type Engine struct {
+	rules []Rule
+}
+
+// NewEngine creates an Engine that evaluates the provided rules.
+func NewEngine(rules ...Rule) *Engine {
+	return &Engine{rules: rules}
+}
+
+// Default creates an Engine with the standard kindsys maturity rules:
+//
+//   - [NoGrafanaMaturityAttributes] at stable and above
+//   - [Compatible] at stable and above
+//   - [FieldDocs] at mature
+func Default() *Engine {
+	return NewEngine(
+		NoGrafanaMaturityAttributes(),
+		Compatible(),
+		FieldDocs(),
+	)
+}
+
+// Rules returns the rules evaluated by the Engine.
+func (e *Engine) Rules() []Rule {
+	return e.rules
+}
+
+// Evaluate checks the kind against each rule that applies at its maturity,
+// returning all violations.
+func (e *Engine) Evaluate(k kindsys.Kind) Violations {
+	var vs Violations
+	for _, r := range e.rules {
+		if k.Maturity().Less(r.Maturity()) {
+			continue
+		}
+		for _, v := range r.Check(k) {
+			v.Rule, v.Kind = r.Name(), k.Name()
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
+
+// Check is [Engine.Evaluate], but returns the violations as an error, or nil
+// if there are none.
+func (e *Engine) Check(k kindsys.Kind) error {
+	if vs := e.Evaluate(k); len(vs) > 0 {
+		return vs
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/grafana/thema"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/kindsys"
+	"github.com/grafana/kindsys/internal/kindtest"
+)
+
+const (
+	// documented, with no @grafanamaturity attributes
+	cleanSchemas = `[{
+	version: [0, 0]
+	schema: {
+		// spec is the spec.
+		spec: {
+			// title is the title.
+			title: string
+		}
+	}
+}]`
+
+	wipSchemas = `[{
+	version: [0, 0]
+	schema: {
+		// spec is the spec.
+		spec: {
+			// title is the title.
+			title: string @grafanamaturity(NeedsExpertReview)
+			count?: int
+		}
+	}
+}]`
+
+	breakingSchemas = `[{
+	version: [0, 0]
+	schema: {
+		// spec is the spec.
+		spec: {
+			// title is the title.
+			title: string
+		}
+	}
+}, {
+	version: [0, 1]
+	schema: {
+		// spec is the spec.
+		spec: {
+			// title is the title.
+			title: int
+		}
+	}
+}]`
+)
+
+func TestDefaultEngine(t *testing.T) {
+	tests := map[string]struct {
+		maturity kindsys.Maturity
+		schemas  string
+		want     []Violation
+	}{
+		"experimental allows anything": {
+			maturity: kindsys.MaturityExperimental,
+			schemas:  wipSchemas,
+		},
+		"stable forbids grafanamaturity": {
+			maturity: kindsys.MaturityStable,
+			schemas:  wipSchemas,
+			want: []Violation{{
+				Rule:    "no-grafanamaturity",
+				Kind:    "TestPolicy",
+				Path:    "spec.title",
+				Message: "@grafanamaturity(NeedsExpertReview) not allowed at maturity stable",
+			}},
+		},
+		"stable forbids breaking minors": {
+			maturity: kindsys.MaturityStable,
+			schemas:  breakingSchemas,
+			want: []Violation{{
+				Rule:    "compatible",
+				Kind:    "TestPolicy",
+				Path:    "spec.title",
+				Message: "breaking change from 0.0 to 0.1: type changed",
+			}},
+		},
+		"mature requires docs": {
+			maturity: kindsys.MaturityMature,
+			schemas:  wipSchemas,
+			want: []Violation{{
+				Rule:    "no-grafanamaturity",
+				Kind:    "TestPolicy",
+				Path:    "spec.title",
+				Message: "@grafanamaturity(NeedsExpertReview) not allowed at maturity mature",
+			}, {
+				Rule:    "field-docs",
+				Kind:    "TestPolicy",
+				Path:    "spec.count",
+				Message: "field has no doc comment",
+			}},
+		},
+		"mature and clean": {
+			maturity: kindsys.MaturityMature,
+			schemas:  cleanSchemas,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			k := bindTestKind(t, tt.maturity, tt.schemas)
+			got := Default().Evaluate(k)
+			require.Equal(t, Violations(tt.want), got)
+
+			err := Default().Check(k)
+			if len(tt.want) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			var vs Violations
+			require.True(t, errors.As(err, &vs))
+		})
+	}
+}
+
+func TestCustomRule(t *testing.T) {
+	rule := RuleFunc{
+		RuleName:    "always",
+		MinMaturity: kindsys.MaturityStable,
+		CheckFunc: func(k kindsys.Kind) []Violation {
+			return []Violation{{Message: "nope"}}
+		},
+	}
+	e := NewEngine(rule)
+
+	require.Empty(t, e.Evaluate(bindTestKind(t, kindsys.MaturityExperimental, cleanSchemas)))
+	require.Equal(t, Violations{{Rule: "always", Kind: "TestPolicy", Message: "nope"}},
+		e.Evaluate(bindTestKind(t, kindsys.MaturityStable, cleanSchemas)))
+}
+
+func bindTestKind(t *testing.T, maturity kindsys.Maturity, schemas string) kindsys.Kind {
+	t.Helper()
+	return kindtest.BindCore(t, `name:        "TestPolicy"
+maturity:    "`+string(maturity)+`"
+description: "A core kind for tests."
+lineage: schemas: `+schemas, thema.SkipBuggyChecks())
+}
@@ -0,0 +1,92 @@
+package policy
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"github.com/grafana/kindsys"
+	"github.com/grafana/kindsys/pkg/compat"
+)
+
+// RuleFunc adapts a func to the [Rule] interface.
+type RuleFunc struct {
+	RuleName    string
+	MinMaturity kindsys.Maturity
+	CheckFunc   func(k kindsys.Kind) []Violation
+}
+
+func (r RuleFunc) Name() string                     { return r.RuleName }
+func (r RuleFunc) Maturity() kindsys.Maturity       { return r.MinMaturity }
+func (r RuleFunc) Check(k kindsys.Kind) []Violation { return r.CheckFunc(k) }
+
+// NoGrafanaMaturityAttributes returns a rule that forbids @grafanamaturity
+// attributes in the latest schema of kinds at stable maturity or above.
+//
+// @grafanamaturity marks fields as still needing work. A kind may not promise
+// stability while it still has any.
+func NoGrafanaMaturityAttributes() Rule {
+	return RuleFunc{
+		RuleName:    "no-grafanamaturity",
+		MinMaturity: kindsys.MaturityStable,
+		CheckFunc: func(k kindsys.Kind) []Violation {
+			// Malformed attributes fail binding, so any error here is moot
+			sa, _ := kindsys.ParseSchemaAttributes(k.Lineage().Latest()) //nolint:errcheck
+			var vs []Violation
+			for _, attr := range sa.Named("grafanamaturity") {
+				vs = append(vs, Violation{
+					Path:    attr.Path,
+					Message: fmt.Sprintf("@grafanamaturity(%s) not allowed at maturity %s", attr.Raw.Contents(), k.Maturity()),
+				})
+			}
+			return vs
+		},
+	}
+}
+
+// Compatible returns a rule that forbids breaking changes between minor
+// versions in the lineage of kinds at stable maturity or above, as classified
+// by [compat.Check].
+func Compatible() Rule {
+	return RuleFunc{
+		RuleName:    "compatible",
+		MinMaturity: kindsys.MaturityStable,
+		CheckFunc: func(k kindsys.Kind) []Violation {
+			r, _ := compat.Check(k) //nolint:errcheck
+			var vs []Violation
+			for _, s := range r.Violations() {
+				for _, c := range s.Breaking() {
+					vs = append(vs, Violation{
+						Path:    c.Path,
+						Message: fmt.Sprintf("breaking change from %s to %s: %s", s.From, s.To, c.Kind),
+					})
+				}
+			}
+			return vs
+		},
+	}
+}
+
+// FieldDocs returns a rule that requires every field in the latest schema of
+// kinds at mature maturity to have a doc comment.
+func FieldDocs() Rule {
+	return RuleFunc{
+		RuleName:    "field-docs",
+		MinMaturity: kindsys.MaturityMature,
+		CheckFunc: func(k kindsys.Kind) []Violation {
+			var vs []Violation
+			kindsys.WalkFields(latestSchema(k), kindsys.WalkFieldsConfig{References: true}, func(f kindsys.Field) {
+				if len(f.Value.Doc()) == 0 {
+					vs = append(vs, Violation{
+						Path:    f.Path,
+						Message: "field has no doc comment",
+					})
+				}
+			})
+			return vs
+		},
+	}
+}
+
+func latestSchema(k kindsys.Kind) cue.Value {
+	return k.Lineage().Latest().Underlying().LookupPath(cue.ParsePath("schema"))
+}
@@ -27,6 +27,7 @@ type CoreProperties struct {
 		Group       string `json:"group"`
 		Scope       string `json:"scope"`
 		DummySchema bool   `json:"dummySchema"`
+		DeepCopy    string `json:"deepCopy"`
 	} `json:"crd"`
 }
 
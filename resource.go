@@ -260,7 +260,15 @@ func (b *BasicMetadataObject) CustomMetadata() CustomMetadata {
 //	func (c *CustomResource) Copy() kindsys.Resource {
 //	    return resource.CopyResource(c)
 //	}
+//
+// If in has a DeepCopyInto method taking its own type, as generated by
+// GoDeepCopyJenny in pkg/codegen, the copy is made with it. Otherwise, the
+// copy is shallow, sharing any maps, slices and pointers with in.
 func CopyResource(in any) Resource {
+	if r, ok := deepCopyResource(in); ok {
+		return r
+	}
+
 	val := reflect.ValueOf(in).Elem()
 
 	cpy := reflect.New(val.Type())
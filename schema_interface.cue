@@ -42,7 +42,8 @@ package kindsys
 //  - The composition logic specifies that the `panelcfg.PanelOptions` from each lineage provided
 //    to the dashboard lineage be one possibility for `panels[].options`
 //
-// (TODO actual implementation is pending https://github.com/grafana/thema/issue/8)
+// (TODO composition in Thema itself is pending https://github.com/grafana/thema/issue/8;
+// until then, kindsys.Compose performs it in Go, given each slot's position)
 //
 // Thus, the dashboard schema used for validation by any particular Grafana instance
 // can tell the user if a particular dashboard with a `timeseries` panel has invalid
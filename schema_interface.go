@@ -2,8 +2,10 @@ package kindsys
 
 import (
 	"fmt"
+	"strings"
 
 	"cuelang.org/go/cue"
+	"github.com/grafana/thema"
 )
 
 // SchemaInterface represents one of Grafana's named schema interfaces.
@@ -100,3 +102,116 @@ func doSchemaInterfaces(ctx *cue.Context) map[string]SchemaInterface {
 
 	return ifaces
 }
+
+// pathContract is the path of the contract of the schema interface
+// implemented by a composable kind, within its definition.
+var pathContract = cue.MakePath(cue.Hid("_schemaInterface", "github.com/grafana/kindsys"), cue.Str("interface"))
+
+// checkContract checks that every schema in the lineage of the composable
+// kind definition def satisfies the contract of the schema interface it
+// implements, returning a [ContractErrors] for those that do not. Lineages
+// implementing an unknown schema interface are not checked, as the set of
+// schema interfaces is open.
+//
+// The contract is taken from def.V, already unified with the kindsys
+// framework, rather than from [SchemaInterfaces], which would build the
+// framework again in each new context.
+func checkContract(def Def[ComposableProperties], lin thema.Lineage) error {
+	defer lockCUE(lin.Runtime().Context())()
+	contract := def.V.LookupPath(pathContract)
+	if !contract.Exists() || contract.Err() != nil {
+		return nil
+	}
+
+	var errs ContractErrors
+	for sch := lin.First(); sch != nil; sch = sch.Successor() {
+		cerr := &ContractError{
+			Interface: def.Properties.SchemaInterface,
+			Schema:    sch.Version(),
+		}
+		checkContractFields("", contract, sch.Underlying().LookupPath(cue.MakePath(cue.Str("schema"))), cerr)
+		if len(cerr.Fields) > 0 {
+			errs = append(errs, cerr)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// checkContractFields checks that the fields of the struct v satisfy those of
+// the contract struct c, recording those that do not in cerr with paths
+// prefixed by p. Fields of v absent from c are not checked.
+func checkContractFields(p string, c, v cue.Value, cerr *ContractError) {
+	iter, err := c.Fields(cue.Optional(true))
+	if err != nil {
+		cerr.Fields = append(cerr.Fields, FieldError{Path: p, Message: err.Error()})
+		return
+	}
+	for iter.Next() {
+		name := iter.Selector().Unquoted()
+		fp := name
+		if p != "" {
+			fp = p + "." + name
+		}
+
+		cf := iter.Value()
+		vf, optional := v.LookupPath(cue.MakePath(cue.Str(name))), false
+		if !vf.Exists() {
+			vf, optional = v.LookupPath(cue.MakePath(cue.Str(name).Optional())), true
+		}
+		switch {
+		case !vf.Exists():
+			if !iter.IsOptional() {
+				cerr.Fields = append(cerr.Fields, FieldError{Path: fp, Message: "missing field required by the schema interface"})
+			}
+			continue
+		case optional && !iter.IsOptional():
+			cerr.Fields = append(cerr.Fields, FieldError{Path: fp, Message: "field is optional, but required by the schema interface"})
+		}
+
+		if cf.IncompleteKind() == cue.StructKind && vf.IncompleteKind() == cue.StructKind {
+			checkContractFields(fp, cf, vf, cerr)
+			continue
+		}
+		if err := cf.Subsume(vf, cue.Schema()); err != nil {
+			cerr.Fields = append(cerr.Fields, FieldError{
+				Path:    fp,
+				Message: fmt.Sprintf("%v is incompatible with %v, as required by the schema interface", vf, cf),
+			})
+		}
+	}
+}
+
+// ContractError describes how a schema in the lineage of a [Composable] kind
+// fails to satisfy the contract of the schema interface it implements.
+type ContractError struct {
+	// Interface is the name of the schema interface.
+	Interface string
+	// Schema is the version of the schema.
+	Schema thema.SyntacticVersion
+	// Fields describes each field of the schema that does not satisfy the
+	// contract.
+	Fields []FieldError
+}
+
+func (e *ContractError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.String())
+	}
+	return fmt.Sprintf("schema %s does not satisfy the %s schema interface: %s", e.Schema, e.Interface, strings.Join(msgs, "; "))
+}
+
+// ContractErrors aggregates the [ContractError]s of each schema in a
+// [Composable] kind's lineage that does not satisfy its schema interface.
+type ContractErrors []*ContractError
+
+func (e ContractErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d schema(s) do not satisfy the schema interface:\n%s", len(e), strings.Join(msgs, "\n"))
+}
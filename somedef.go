@@ -2,8 +2,10 @@ package kindsys
 
 import (
 	"fmt"
+	"strings"
 
 	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/errors"
 	"github.com/grafana/thema"
 )
 
@@ -16,6 +18,11 @@ type SomeDef struct {
 	V cue.Value
 	// Properties contains the kind's declarative non-schema properties.
 	Properties SomeKindProperties
+	// Dir is the slash-separated path of the directory from which the
+	// definition was loaded, relative to the root of the fs.FS it was loaded
+	// from, as by [LoadKindDef]. It is empty for definitions not loaded from a
+	// filesystem, such as those created by [ToDef].
+	Dir string
 }
 
 // BindKindLineage binds the lineage for the kind definition.
@@ -23,11 +30,23 @@ type SomeDef struct {
 // For kinds with a corresponding Go type, it is left to the caller to associate
 // that Go type with the lineage returned from this function by a call to
 // [thema.BindType].
+//
+// All attributes with a registered parser in the lineage's schemas are
+// validated, and any malformed ones returned as an [AttributeErrors]. See
+// [RegisterAttribute].
 func (def SomeDef) BindKindLineage(rt *thema.Runtime, opts ...thema.BindOption) (thema.Lineage, error) {
 	if rt == nil {
 		return nil, fmt.Errorf("nil thema.Runtime")
 	}
-	return thema.BindLineage(def.V.LookupPath(cue.MakePath(cue.Str("lineage"))), rt, opts...)
+	defer lockCUE(rt.Context())()
+	lin, err := thema.BindLineage(def.V.LookupPath(cue.MakePath(cue.Str("lineage"))), rt, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateAttributes(lin); err != nil {
+		return nil, err
+	}
+	return lin, nil
 }
 
 // IsCore indicates whether the represented kind is a core kind.
@@ -48,6 +67,122 @@ func (def SomeDef) IsComposable() bool {
 	return is
 }
 
+// ToSomeDef takes a cue.Value expected to represent a kind of any category,
+// determines that category, and populates a SomeDef from the CUE value.
+//
+// If the value explicitly embeds one of the kindsys CUE kind definitions (e.g.
+// `kindsys.Core`), that determines the category. Otherwise, the value is
+// checked against each category in turn. The underlying type of the returned
+// SomeDef's Properties indicates the category. Categories not embedded are
+// taken from the latest of [FrameworkVersions].
+//
+// If the value is not a valid kind of any category, the returned error is a
+// [*CategoryError] reporting why each category failed.
+func ToSomeDef(v cue.Value) (SomeDef, error) {
+	return toSomeDef(v, latestFramework())
+}
+
+// toSomeDef is [ToSomeDef], but checks against the kind categories from the
+// specified version of the kindsys CUE framework. See toDef.
+func toSomeDef(v cue.Value, fwversion string) (SomeDef, error) {
+	if !v.Exists() {
+		return SomeDef{}, ErrValueNotExist
+	}
+
+	unlock := lockCUE(v.Context())
+	cat := embeddedCategory(v)
+	unlock()
+
+	switch cat {
+	case "Core":
+		def, err := toDef[CoreProperties](v, fwversion)
+		return def.Some(), err
+	case "Custom":
+		def, err := toDef[CustomProperties](v, fwversion)
+		return def.Some(), err
+	case "Composable":
+		def, err := toDef[ComposableProperties](v, fwversion)
+		return def.Some(), err
+	}
+
+	cerr := &CategoryError{}
+	var found []SomeDef
+	if def, err := toDef[CoreProperties](v, fwversion); err != nil {
+		cerr.Core = err
+	} else {
+		found = append(found, def.Some())
+	}
+	if def, err := toDef[CustomProperties](v, fwversion); err != nil {
+		cerr.Custom = err
+	} else {
+		found = append(found, def.Some())
+	}
+	if def, err := toDef[ComposableProperties](v, fwversion); err != nil {
+		cerr.Composable = err
+	} else {
+		found = append(found, def.Some())
+	}
+
+	switch len(found) {
+	case 0:
+		return SomeDef{}, cerr
+	case 1:
+		return found[0], nil
+	default:
+		return SomeDef{}, fmt.Errorf("%w: value is valid as more than one kind category, embed one of kindsys.Core, kindsys.Custom or kindsys.Composable to disambiguate", ErrValueNotAKind)
+	}
+}
+
+// CategoryError is returned from [ToSomeDef] when a value is not a valid kind
+// of any category. Each field holds the reason the value failed to be a kind
+// of the corresponding category.
+//
+// CategoryError matches [ErrValueNotAKind] with errors.Is.
+type CategoryError struct {
+	Core       error
+	Custom     error
+	Composable error
+}
+
+func (e *CategoryError) Error() string {
+	var b strings.Builder
+	b.WriteString("not a kind of any category:")
+	for _, c := range []struct {
+		name string
+		err  error
+	}{{"Core", e.Core}, {"Custom", e.Custom}, {"Composable", e.Composable}} {
+		fmt.Fprintf(&b, "\n\t%s: %s", c.name, firstCUEError(c.err))
+	}
+	return b.String()
+}
+
+func (e *CategoryError) Is(target error) bool {
+	return target == ErrValueNotAKind
+}
+
+// firstCUEError renders only the first of possibly many CUE errors, along with
+// its position, to keep category mismatch reports readable.
+func firstCUEError(err error) string {
+	// Peel off the ErrValueNotAKind wrapping added by ToDef
+	if cause := errors.Unwrap(err); cause != nil && errors.Is(err, ErrValueNotAKind) {
+		err = cause
+	}
+	errs := errors.Errors(err)
+	if len(errs) == 0 {
+		return err.Error()
+	}
+
+	first := errs[0]
+	msg := strings.TrimPrefix(first.Error(), ErrValueNotAKind.Error()+": ")
+	if pos := first.Position(); pos.IsValid() {
+		msg += " (" + pos.String() + ")"
+	}
+	if len(errs) > 1 {
+		msg += fmt.Sprintf(" (and %d more errors)", len(errs)-1)
+	}
+	return msg
+}
+
 // Def represents a single kind definition, having been loaded and validated by
 // a func such as [LoadCoreKindDef].
 //
@@ -60,6 +195,11 @@ type Def[T KindProperties] struct {
 	V cue.Value
 	// Properties contains the kind's declarative non-schema properties.
 	Properties T
+	// Dir is the slash-separated path of the directory from which the
+	// definition was loaded, relative to the root of the fs.FS it was loaded
+	// from, as by [LoadKindDef]. It is empty for definitions not loaded from a
+	// filesystem, such as those created by [ToDef].
+	Dir string
 }
 
 // Some converts the typed Def to the equivalent typeless SomeDef.
@@ -67,5 +207,6 @@ func (def Def[T]) Some() SomeDef {
 	return SomeDef{
 		V:          def.V,
 		Properties: any(def.Properties).(SomeKindProperties),
+		Dir:        def.Dir,
 	}
 }
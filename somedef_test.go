@@ -0,0 +1,91 @@
+package kindsys
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToSomeDef(t *testing.T) {
+	tt := map[string]struct {
+		src string
+		is  func(SomeDef) bool
+	}{
+		"embedded core": {
+			src: `
+import "github.com/grafana/kindsys"
+
+kindsys.Core
+name:        "EmbeddedCore"
+maturity:    "experimental"
+description: "Category from the embedded marker."
+lineage: schemas: [{version: [0, 0], schema: spec: a: string}]
+`,
+			is: SomeDef.IsCore,
+		},
+		"inferred core": {
+			src: `
+name:        "InferredCore"
+maturity:    "experimental"
+description: "Category from trial unification."
+lineage: schemas: [{version: [0, 0], schema: spec: a: string}]
+`,
+			is: SomeDef.IsCore,
+		},
+		"inferred custom": {
+			src: `
+name:  "InferredCustom"
+group: "someapp"
+lineage: schemas: [{version: [0, 0], schema: a: string}]
+`,
+			is: SomeDef.IsCustom,
+		},
+		"inferred composable": {
+			src: `
+name:            "InferredPanelCfg"
+schemaInterface: "PanelCfg"
+lineage: name:   "inferredpanelcfg"
+lineage: schemas: [{version: [0, 0], schema: Options: a: string}]
+`,
+			is: SomeDef.IsComposable,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			v, err := BuildInstance(nil, "kind", "kind", testKindFS("package kind\n"+tc.src))
+			require.NoError(t, err)
+
+			def, err := ToSomeDef(v)
+			require.NoError(t, err)
+			require.True(t, tc.is(def))
+		})
+	}
+}
+
+func TestToSomeDefNotAKind(t *testing.T) {
+	v := ctx.CompileString(`
+name:     "NoCategory"
+maturity: "experimental"
+lineage: schemas: [{version: [0, 0], schema: spec: a: string}]
+`)
+
+	_, err := ToSomeDef(v)
+	require.True(t, errors.Is(err, ErrValueNotAKind))
+
+	var cerr *CategoryError
+	require.True(t, errors.As(err, &cerr))
+	require.Error(t, cerr.Core)
+	require.Error(t, cerr.Custom)
+	require.Error(t, cerr.Composable)
+	require.Contains(t, err.Error(), "description")
+	require.Contains(t, err.Error(), "group")
+	require.Contains(t, err.Error(), "schemaInterface")
+}
+
+func testKindFS(src string) fs.FS {
+	return fstest.MapFS{"kind.cue": &fstest.MapFile{Data: []byte(src)}}
+}
@@ -0,0 +1 @@
+module: "github.com/grafana/kindsys"
@@ -23,44 +23,25 @@ func (u *UnstructuredResource) Subresources() map[string]any {
 	}
 }
 
+// Copy returns a deep copy of the UnstructuredResource.
 func (u *UnstructuredResource) Copy() Resource {
-	com := CommonMetadata{
-		UID:               u.CommonMeta.UID,
-		ResourceVersion:   u.CommonMeta.ResourceVersion,
-		CreationTimestamp: u.CommonMeta.CreationTimestamp.UTC(),
-		UpdateTimestamp:   u.CommonMeta.UpdateTimestamp.UTC(),
-		CreatedBy:         u.CommonMeta.CreatedBy,
-		UpdatedBy:         u.CommonMeta.UpdatedBy,
-	}
-
-	copy(u.CommonMeta.Finalizers, com.Finalizers)
-	if u.CommonMeta.DeletionTimestamp != nil {
-		*com.DeletionTimestamp = *(u.CommonMeta.DeletionTimestamp)
-	}
-	for k, v := range u.CommonMeta.Labels {
-		com.Labels[k] = v
-	}
-	com.ExtraFields = mapcopy(u.CommonMeta.ExtraFields)
-
-	cp := UnstructuredResource{
-		Spec:   mapcopy(u.Spec),
-		Status: mapcopy(u.Status),
-	}
+	return u.DeepCopy()
+}
 
-	cp.CommonMeta = com
-	cp.CustomMeta = mapcopy(u.CustomMeta)
-	return &cp
+// DeepCopyInto copies the receiver into out, sharing no maps, slices or
+// pointers with it.
+func (u *UnstructuredResource) DeepCopyInto(out *UnstructuredResource) {
+	u.BasicMetadataObject.DeepCopyInto(&out.BasicMetadataObject)
+	out.Spec = deepCopyMap(u.Spec)
+	out.Status = deepCopyMap(u.Status)
 }
 
-func mapcopy(m map[string]any) map[string]any {
-	cp := make(map[string]any)
-	for k, v := range m {
-		if vm, ok := v.(map[string]any); ok {
-			cp[k] = mapcopy(vm)
-		} else {
-			cp[k] = v
-		}
+// DeepCopy returns a deep copy of the receiver.
+func (u *UnstructuredResource) DeepCopy() *UnstructuredResource {
+	if u == nil {
+		return nil
 	}
-
-	return cp
+	out := new(UnstructuredResource)
+	u.DeepCopyInto(out)
+	return out
 }
@@ -0,0 +1,101 @@
+package kindsys
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnstructuredResource_Copy(t *testing.T) {
+	// zero values have nil maps, slices and pointers
+	require.Equal(t, &UnstructuredResource{}, (&UnstructuredResource{}).Copy())
+
+	del := time.Date(2023, 7, 6, 3, 8, 1, 0, time.FixedZone("CEST", 2*60*60))
+	u := &UnstructuredResource{
+		Spec:   map[string]any{"list": []any{map[string]any{"a": 1.0}}},
+		Status: map[string]any{"state": "ok"},
+	}
+	u.CommonMeta.DeletionTimestamp = &del
+	u.CommonMeta.Finalizers = []string{"foo"}
+	u.CommonMeta.Labels = map[string]string{"foo": "bar"}
+	u.CustomMeta = SimpleCustomMetadata{"foo": "bar"}
+
+	cp := u.Copy().(*UnstructuredResource)
+	require.Equal(t, u, cp)
+	require.NotSame(t, u.CommonMeta.DeletionTimestamp, cp.CommonMeta.DeletionTimestamp)
+	cp.CommonMeta.Finalizers[0] = "baz"
+	cp.Spec["list"].([]any)[0].(map[string]any)["a"] = 2.0
+	require.Equal(t, "foo", u.CommonMeta.Finalizers[0])
+	require.Equal(t, 1.0, u.Spec["list"].([]any)[0].(map[string]any)["a"])
+}
+
+func TestCopyResource(t *testing.T) {
+	// CopyResource uses the DeepCopyInto method of UnstructuredResource, rather
+	// than the promoted one of BasicMetadataObject
+	u := &UnstructuredResource{Spec: map[string]any{"a": map[string]any{"b": "c"}}}
+	cp := CopyResource(u).(*UnstructuredResource)
+	cp.Spec["a"].(map[string]any)["b"] = "d"
+	require.Equal(t, "c", u.Spec["a"].(map[string]any)["b"])
+
+	// without a DeepCopyInto method of its own, the copy is shallow
+	r := &testResource{}
+	r.CustomMeta = SimpleCustomMetadata{"foo": "bar"}
+	rcp := CopyResource(r).(*testResource)
+	require.NotSame(t, r, rcp)
+	require.Equal(t, r, rcp)
+}
+
+func FuzzUnstructuredResource_Copy(f *testing.F) {
+	f.Add([]byte(`{}`))
+	f.Add([]byte(`{"spec": {"a": [1, "b", {"c": null}]}, "status": {"d": {}}}`))
+	f.Add([]byte(`{"commonMetadata": {"labels": {"a": "b"}, "finalizers": ["c"], "deletionTimestamp": "2023-07-06T03:08:01Z", "extraFields": {"e": [{}]}}, "customMetadata": {"f": "g"}}`))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		u := new(UnstructuredResource)
+		if err := json.Unmarshal(b, u); err != nil {
+			t.Skip()
+		}
+		before, err := json.Marshal(u)
+		require.NoError(t, err)
+
+		cp := u.Copy().(*UnstructuredResource)
+		require.Equal(t, u, cp)
+
+		// mutating everything in the copy leaves the original untouched
+		mutate(cp.Spec)
+		mutate(cp.Status)
+		mutate(cp.CommonMeta.ExtraFields)
+		mutate(cp.CustomMeta)
+		for k := range cp.CommonMeta.Labels {
+			cp.CommonMeta.Labels[k] += "x"
+		}
+		for i := range cp.CommonMeta.Finalizers {
+			cp.CommonMeta.Finalizers[i] += "x"
+		}
+		if cp.CommonMeta.DeletionTimestamp != nil {
+			*cp.CommonMeta.DeletionTimestamp = cp.CommonMeta.DeletionTimestamp.Add(time.Hour)
+		}
+		after, err := json.Marshal(u)
+		require.NoError(t, err)
+		require.JSONEq(t, string(before), string(after))
+	})
+}
+
+// mutate changes every value in the JSON value v, in place.
+func mutate(v any) {
+	switch x := v.(type) {
+	case map[string]any:
+		for k, e := range x {
+			mutate(e)
+			x[k] = []any{e}
+		}
+	case SimpleCustomMetadata:
+		mutate(map[string]any(x))
+	case []any:
+		for i, e := range x {
+			mutate(e)
+			x[i] = map[string]any{"x": e}
+		}
+	}
+}
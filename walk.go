@@ -0,0 +1,83 @@
+package kindsys
+
+import (
+	"cuelang.org/go/cue"
+)
+
+// maxWalkDepth bounds the recursion of [WalkFields], guarding against
+// recursive schemas.
+const maxWalkDepth = 32
+
+// Field is a single field visited by [WalkFields].
+type Field struct {
+	// Path is the path of the field from the walked value. Fields of list
+	// elements have "[]" in their path, e.g. "spec.panels[].title".
+	Path string
+	// Selector is the field's selector within its parent struct.
+	Selector cue.Selector
+	// Value is the field's value.
+	Value cue.Value
+}
+
+// WalkFieldsConfig controls which fields [WalkFields] visits.
+type WalkFieldsConfig struct {
+	// Definitions indicates whether definitions, such as #Panel, are visited
+	// along with regular and optional fields.
+	Definitions bool
+	// References indicates whether the fields of values that refer to another
+	// value, such as a definition, are walked. The referring field itself is
+	// visited either way. Leaving this false visits the fields of a
+	// definition only where it is declared, when Definitions is true.
+	References bool
+}
+
+// WalkFields calls fn for each field, including optional fields, in the
+// struct value v and its descendants, depth first, as selected by cfg.
+//
+// The caller must hold any lock needed to operate on v's [cue.Context].
+func WalkFields(v cue.Value, cfg WalkFieldsConfig, fn func(f Field)) {
+	opts := []cue.Option{cue.Optional(true)}
+	if cfg.Definitions {
+		opts = append(opts, cue.Definitions(true))
+	}
+	follow := func(v cue.Value) bool {
+		return cfg.References || !IsReference(v)
+	}
+
+	var walk func(p string, v cue.Value, depth int)
+	walk = func(p string, v cue.Value, depth int) {
+		if depth > maxWalkDepth {
+			return
+		}
+		switch v.IncompleteKind() {
+		case cue.StructKind:
+			iter, err := v.Fields(opts...)
+			if err != nil {
+				return
+			}
+			for iter.Next() {
+				fp := iter.Selector().String()
+				if p != "" {
+					fp = p + "." + fp
+				}
+				fn(Field{Path: fp, Selector: iter.Selector(), Value: iter.Value()})
+				if follow(iter.Value()) {
+					walk(fp, iter.Value(), depth+1)
+				}
+			}
+		case cue.ListKind:
+			if elem := v.LookupPath(cue.MakePath(cue.AnyIndex)); elem.Exists() && follow(elem) {
+				walk(p+"[]", elem, depth+1)
+			}
+		}
+	}
+	walk("", v, 0)
+}
+
+// IsReference indicates whether v is a reference to another value, such as a
+// definition. [WalkFields] does not walk the fields of references unless
+// configured to.
+func IsReference(v cue.Value) bool {
+	_, p := v.ReferencePath()
+	return len(p.Selectors()) > 0
+}
@@ -0,0 +1,48 @@
+package kindsys
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkFields(t *testing.T) {
+	v := cuecontext.New().CompileString(`
+#Target: {
+	expr: string
+}
+spec: {
+	title:   string
+	panels?: [...{
+		target: #Target
+	}]
+}
+`)
+	require.NoError(t, v.Err())
+
+	tests := []struct {
+		cfg   WalkFieldsConfig
+		paths []string
+	}{
+		{
+			cfg:   WalkFieldsConfig{},
+			paths: []string{"spec", "spec.title", "spec.panels", "spec.panels[].target"},
+		},
+		{
+			cfg:   WalkFieldsConfig{References: true},
+			paths: []string{"spec", "spec.title", "spec.panels", "spec.panels[].target", "spec.panels[].target.expr"},
+		},
+		{
+			cfg:   WalkFieldsConfig{Definitions: true},
+			paths: []string{"#Target", "#Target.expr", "spec", "spec.title", "spec.panels", "spec.panels[].target"},
+		},
+	}
+	for _, tt := range tests {
+		var paths []string
+		WalkFields(v, tt.cfg, func(f Field) {
+			paths = append(paths, f.Path)
+		})
+		require.Equal(t, tt.paths, paths, "%+v", tt.cfg)
+	}
+}